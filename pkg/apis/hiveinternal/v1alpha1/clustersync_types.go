@@ -0,0 +1,146 @@
+/*
+Copyright 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1alpha1"
+)
+
+// ClusterSyncConditionType is a valid value for ClusterSyncCondition.Type.
+type ClusterSyncConditionType string
+
+const (
+	// ClusterSyncFailed is true when the most recent attempt to apply SyncSets or
+	// SelectorSyncSets to the cluster encountered an error.
+	ClusterSyncFailed ClusterSyncConditionType = "Failed"
+)
+
+// ClusterSyncCondition contains details for the current condition of a ClusterSync
+type ClusterSyncCondition struct {
+	// Type is the type of the condition.
+	Type ClusterSyncConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about the last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterSyncSpec defines the desired state of ClusterSync. ClusterSync is created and owned
+// entirely by the syncset controller, so it has no user-configurable spec.
+type ClusterSyncSpec struct {
+}
+
+// ClusterSyncStatus defines the observed state of ClusterSync
+type ClusterSyncStatus struct {
+	// SyncSets is the sync status of all SyncSets for the ClusterDeployment.
+	// +optional
+	SyncSets []hivev1.SyncSetObjectStatus `json:"syncSets,omitempty"`
+
+	// SelectorSyncSets is the sync status of all SelectorSyncSets for the ClusterDeployment.
+	// +optional
+	SelectorSyncSets []hivev1.SyncSetObjectStatus `json:"selectorSyncSets,omitempty"`
+
+	// Conditions is a list of conditions that summarize the overall health of syncing to this
+	// cluster.
+	// +optional
+	Conditions []ClusterSyncCondition `json:"conditions,omitempty"`
+
+	// ResourceStatuses is the live runtime status of resources a SyncSet or SelectorSyncSet
+	// created on the target cluster (Deployments, StatefulSets, DaemonSets, Jobs, Pods, Services,
+	// Ingresses and ConfigMaps), reduced into a compact per-object status.
+	// +optional
+	ResourceStatuses []ResourceStatus `json:"resourceStatuses,omitempty"`
+
+	// SyncSetResourcesReady aggregates ResourceStatuses by the SyncSet or SelectorSyncSet that
+	// owns them. Ready is true only when every watched resource that SyncSet applied reports
+	// ready.
+	// +optional
+	SyncSetResourcesReady []SyncSetResourcesReady `json:"syncSetResourcesReady,omitempty"`
+}
+
+// ResourceStatus is the condensed, Hive-facing runtime status of a single resource a SyncSet or
+// SelectorSyncSet applied to the target cluster.
+type ResourceStatus struct {
+	// APIVersion is the API version of the resource.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// SyncSetName is the name of the SyncSet or SelectorSyncSet that applied this resource.
+	// +optional
+	SyncSetName string `json:"syncSetName,omitempty"`
+	// Ready indicates whether hive considers this resource's current runtime state healthy,
+	// using a kind-specific reduction (e.g. replicas > 0 and readyReplicas >= replicas for a
+	// Deployment).
+	Ready bool `json:"ready"`
+	// Message is a short, kind-specific human-readable summary of the resource's runtime state.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SyncSetResourcesReady aggregates the readiness of every resource a single SyncSet or
+// SelectorSyncSet applied.
+type SyncSetResourcesReady struct {
+	// Name is the name of the SyncSet or SelectorSyncSet.
+	Name string `json:"name"`
+	// Ready is true only when every resource the named SyncSet or SelectorSyncSet applied
+	// reports ready.
+	Ready bool `json:"ready"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterSync holds per-ClusterDeployment bookkeeping for SyncSet and SelectorSyncSet
+// application. It has the same name and namespace as the ClusterDeployment it belongs to, and is
+// created and managed entirely by the syncset controller. Keeping this bookkeeping out of
+// ClusterDeployment.Status keeps that object focused on cluster provisioning, and gives
+// observability tooling a single, RBAC-scopable resource to watch rather than requiring it to
+// parse embedded status lists off of every ClusterDeployment.
+type ClusterSync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterSyncSpec   `json:"spec,omitempty"`
+	Status ClusterSyncStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterSyncList contains a list of ClusterSync
+type ClusterSyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSync `json:"items"`
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterSyncLeaseSpec defines the observed state of a ClusterSyncLease.
+type ClusterSyncLeaseSpec struct {
+	// RenewTime is the last time the lease was renewed by the hive replica that applied
+	// SyncSets and SelectorSyncSets to this cluster.
+	// +optional
+	RenewTime *metav1.MicroTime `json:"renewTime,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterSyncLease records when a cluster's SyncSets and SelectorSyncSets were last reapplied
+// regardless of content changes, and throttles that periodic reapply to at most once per fleet-wide
+// default reapply interval, whatever ReapplyInterval a SyncSet sets. RenewTime is shared fleet-wide state: a replica only renews it once the interval has
+// elapsed, so a conflicting update means another replica already renewed it this cycle and this
+// reconcile should skip the periodic reapply. This staggers periodic reapply across the fleet by
+// ClusterDeployment rather than firing everything at once after a hive restart; it does not
+// partition or claim ownership of a ClusterDeployment's other reconcile work, which every replica
+// still performs as usual. It has the same name and namespace as the ClusterDeployment it belongs
+// to.
+type ClusterSyncLease struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterSyncLeaseSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterSyncLeaseList contains a list of ClusterSyncLease
+type ClusterSyncLeaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSyncLease `json:"items"`
+}
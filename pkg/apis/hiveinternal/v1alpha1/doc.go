@@ -0,0 +1,23 @@
+/*
+Copyright 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains API Schema definitions for the hiveinternal v1alpha1 API group. These
+// types are implementation details the hive controllers use to keep track of their own work
+// (e.g. per-ClusterDeployment sync bookkeeping); unlike hive/pkg/apis/hive, they are not part of
+// the API that SyncSet/SelectorSyncSet authors are expected to create or edit directly.
+// +k8s:deepcopy-gen=package,register
+// +groupName=hiveinternal.openshift.io
+package v1alpha1
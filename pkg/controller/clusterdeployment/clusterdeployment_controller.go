@@ -26,14 +26,20 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1alpha1"
+	"github.com/openshift/hive/pkg/controller/utils/finalizers"
 
 	kbatch "k8s.io/api/batch/v1"
 	kapi "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -47,17 +53,165 @@ import (
 const (
 	installerImage   = "registry.svc.ci.openshift.org/openshift/origin-v4.0:installer"
 	uninstallerImage = "registry.svc.ci.openshift.org/openshift/origin-v4.0:installer" // TODO
+	uploaderImage    = "registry.svc.ci.openshift.org/openshift/origin-v4.0:cli"
+
+	installerWorkDir    = "/output"
+	kubeconfigSecretKey = "kubeconfig"
+	passwordSecretKey   = "password"
+
+	// installerDoneSentinel is touched by the installer container in installerWorkDir when it
+	// exits, success or failure, so the uploader sidecar watching the same volume knows to stop
+	// waiting for credentials that a failed install will never produce.
+	installerDoneSentinel = ".installer-done"
+
+	// installerServiceAccountName is the ServiceAccount the installer Job's credential-uploader
+	// sidecar runs as, scoped by a namespaced Role to only what it needs: creating/updating the
+	// admin kubeconfig and kubeadmin password Secrets it uploads.
+	installerServiceAccountName = "hive-installer"
+
+	// uninstallAnnotation, when set to "true" on a ClusterDeployment, triggers a deprovision of the
+	// remote cluster's cloud resources without deleting the ClusterDeployment object itself.
+	uninstallAnnotation = "hive.openshift.io/uninstalling"
+
+	// hiveNamespace is the namespace Hive itself runs in, and where ClusterRegistry kubeconfig
+	// Secrets are expected to live.
+	hiveNamespace = "hive"
+
+	// remoteClusterRegistryConfigMap is the name of the ConfigMap, in hiveNamespace, whose "clusters"
+	// key holds a YAML-encoded list of ClusterRegistryEntry. Its absence means remote-manager mode is
+	// disabled; this Hive instance only reconciles ClusterDeployments on its own cluster.
+	remoteClusterRegistryConfigMap = "hive-remote-clusters"
 )
 
-// Add creates a new ClusterDeployment Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
-// and Start it when the Manager is Started.
+// ClusterRegistryEntry identifies a remote "hive host" cluster whose ClusterDeployments this Hive
+// instance should also reconcile, in addition to the cluster it is itself running on.
+type ClusterRegistryEntry struct {
+	// Name identifies the remote cluster, used for logging only.
+	Name string
+	// KubeconfigSecretName is the name of a Secret, in hiveNamespace on the local cluster, containing
+	// a kubeconfig for the remote cluster.
+	KubeconfigSecretName string
+}
+
+// Add creates a new ClusterDeployment Controller and adds it to the Manager with default RBAC. The
+// Manager will set fields on the Controller and Start it when the Manager is Started.
+//
+// If the remoteClusterRegistryConfigMap exists in hiveNamespace, Add also builds and starts a
+// Manager (and ClusterDeployment controller) for each remote "hive host" cluster it lists, putting
+// this Hive instance into remote-manager mode.
 func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+	if err := add(mgr, newReconciler(mgr, "")); err != nil {
+		return err
+	}
+
+	registry, err := loadClusterRegistry(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	if len(registry) == 0 {
+		return nil
+	}
+
+	remoteMgrs, err := AddRemoteClusters(mgr, registry)
+	if err != nil {
+		return err
+	}
+	for _, remoteMgr := range remoteMgrs {
+		remoteMgr := remoteMgr
+		// Register each remote Manager as a Runnable on mgr rather than starting it on a signal
+		// handler of our own: signals.SetupSignalHandler may only be called once per process, and
+		// whatever calls mgr.Start(signals.SetupSignalHandler()) in main already owns that call.
+		// mgr ties a Runnable's lifecycle to its own, passing its stop channel through to Start
+		// when it runs and waiting for it to return on shutdown.
+		if err := mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+			return remoteMgr.Start(stop)
+		})); err != nil {
+			return fmt.Errorf("error registering remote cluster manager: %v", err)
+		}
+	}
+	return nil
 }
 
-// newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileClusterDeployment{Client: mgr.GetClient(), scheme: mgr.GetScheme()}
+// loadClusterRegistry reads the remote cluster registry ConfigMap with a direct (uncached) client
+// built from cfg, since the Manager's cache is not yet running when Add is called. A missing
+// ConfigMap is not an error: remote-manager mode is opt-in and loadClusterRegistry returns an empty
+// registry in that case.
+func loadClusterRegistry(cfg *rest.Config) ([]ClusterRegistryEntry, error) {
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("error building client to read remote cluster registry: %v", err)
+	}
+	cm := &kapi.ConfigMap{}
+	key := types.NamespacedName{Name: remoteClusterRegistryConfigMap, Namespace: hiveNamespace}
+	switch err := c.Get(context.TODO(), key, cm); {
+	case errors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("error reading remote cluster registry configmap: %v", err)
+	}
+	var registry []ClusterRegistryEntry
+	if err := yaml.Unmarshal([]byte(cm.Data["clusters"]), &registry); err != nil {
+		return nil, fmt.Errorf("error parsing remote cluster registry configmap: %v", err)
+	}
+	return registry, nil
+}
+
+// AddRemoteClusters builds an independent Manager for each cluster in registry and registers a
+// ClusterDeployment controller against it, so ClusterDeployments living on remote "hive host"
+// clusters are reconciled too. Callers are responsible for Start()ing each returned Manager
+// alongside localMgr.
+func AddRemoteClusters(localMgr manager.Manager, registry []ClusterRegistryEntry) ([]manager.Manager, error) {
+	// A direct (uncached) client, like loadClusterRegistry uses: AddRemoteClusters runs from Add,
+	// before localMgr's cache has been started, so localMgr.GetClient() would block or error trying
+	// to read the kubeconfig Secrets here.
+	directClient, err := client.New(localMgr.GetConfig(), client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("error building client to read remote cluster kubeconfigs: %v", err)
+	}
+
+	remoteMgrs := make([]manager.Manager, 0, len(registry))
+	for _, entry := range registry {
+		kubeConfig, err := loadRemoteKubeconfig(directClient, entry.KubeconfigSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("error loading kubeconfig for remote cluster %q: %v", entry.Name, err)
+		}
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error building rest config for remote cluster %q: %v", entry.Name, err)
+		}
+		remoteMgr, err := manager.New(restConfig, manager.Options{})
+		if err != nil {
+			return nil, fmt.Errorf("error building manager for remote cluster %q: %v", entry.Name, err)
+		}
+		if err := add(remoteMgr, newReconciler(remoteMgr, entry.Name)); err != nil {
+			return nil, fmt.Errorf("error adding controller for remote cluster %q: %v", entry.Name, err)
+		}
+		remoteMgrs = append(remoteMgrs, remoteMgr)
+	}
+	return remoteMgrs, nil
+}
+
+func loadRemoteKubeconfig(c client.Client, secretName string) ([]byte, error) {
+	s := &kapi.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: hiveNamespace}, s); err != nil {
+		return nil, err
+	}
+	data, ok := s.Data[kubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s did not contain key %s", secretName, kubeconfigSecretKey)
+	}
+	return data, nil
+}
+
+// newReconciler returns a new reconcile.Reconciler. clusterName identifies the management cluster
+// mgr is bound to, for logging and diagnostics; it is empty for the cluster hosting Hive itself.
+func newReconciler(mgr manager.Manager, clusterName string) reconcile.Reconciler {
+	return &ReconcileClusterDeployment{
+		Client:      mgr.GetClient(),
+		scheme:      mgr.GetScheme(),
+		clusterName: clusterName,
+		recorder:    mgr.GetRecorder("clusterdeployment-controller"),
+	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -92,6 +246,12 @@ var _ reconcile.Reconciler = &ReconcileClusterDeployment{}
 type ReconcileClusterDeployment struct {
 	client.Client
 	scheme *runtime.Scheme
+
+	// clusterName identifies which management cluster this reconciler's Client is bound to, in
+	// remote-manager / fleet-of-fleets mode. Empty for the cluster hosting Hive itself.
+	clusterName string
+
+	recorder record.EventRecorder
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -118,36 +278,54 @@ func (r *ReconcileClusterDeployment) Reconcile(request reconcile.Request) (recon
 	cdLog := log.WithFields(log.Fields{
 		"clusterDeployment": cd.Name,
 		"namespace":         cd.Namespace,
+		"managementCluster": r.clusterName,
 	})
 	cdLog.Info("reconciling cluster deployment")
+
+	// Finalizer handling comes before any other reconcile work: if we're being deleted but never
+	// got the finalizer, there's nothing to clean up. If we're not being deleted and don't have the
+	// finalizer yet, add it and return without generating any install resources, so a
+	// ClusterDeployment that's deleted moments after creation never has Jobs/ConfigMaps created for
+	// it in the first place.
+	if cd.DeletionTimestamp != nil {
+		if !finalizers.HasFinalizer(cd, hivev1.FinalizerDeprovision) {
+			return reconcile.Result{}, nil
+		}
+		return r.syncDeletedClusterDeployment(cd, cdLog)
+	}
+
+	if !finalizers.HasFinalizer(cd, hivev1.FinalizerDeprovision) {
+		cdLog.Debugf("adding clusterdeployment finalizer")
+		return reconcile.Result{}, r.addClusterDeploymentFinalizer(cd)
+	}
+
+	if isUninstallTriggered(cd) {
+		cdLog.Info("uninstall annotation is set, deprovisioning without deleting cluster deployment")
+		return r.syncUninstallAnnotation(cd, cdLog)
+	}
+
 	origCD := cd.DeepCopy()
 
-	job, cfgMap, err := generateInstallerJob(fmt.Sprintf("%s-install", cd.Name), cd, installerImage, kapi.PullIfNotPresent, false, nil, r.scheme)
+	installerSA, err := r.ensureInstallerServiceAccount(cd, cdLog)
 	if err != nil {
-		cdLog.Errorf("error generating install job", err)
+		cdLog.WithError(err).Error("error ensuring installer service account")
 		return reconcile.Result{}, err
 	}
 
-	if err := controllerutil.SetControllerReference(cd, job, r.scheme); err != nil {
-		cdLog.Errorf("error setting controller reference on job", err)
+	job, cfgMap, err := generateInstallerJob(fmt.Sprintf("%s-install", cd.Name), cd, installerImage, kapi.PullIfNotPresent, false, installerSA, r.scheme)
+	if err != nil {
+		cdLog.WithError(err).Error("error generating install job")
 		return reconcile.Result{}, err
 	}
 
-	if err := controllerutil.SetControllerReference(cd, cfgMap, r.scheme); err != nil {
-		cdLog.Errorf("error setting controller reference on config map", err)
+	if err := controllerutil.SetControllerReference(cd, job, r.scheme); err != nil {
+		cdLog.WithError(err).Error("error setting controller reference on job")
 		return reconcile.Result{}, err
 	}
 
-	if cd.DeletionTimestamp != nil {
-		if !HasFinalizer(cd, hivev1.FinalizerDeprovision) {
-			return reconcile.Result{}, nil
-		}
-		return r.syncDeletedClusterDeployment(cd, cdLog)
-	}
-
-	if !HasFinalizer(cd, hivev1.FinalizerDeprovision) {
-		cdLog.Debugf("adding clusterdeployment finalizer")
-		return reconcile.Result{}, r.addClusterDeploymentFinalizer(cd)
+	if err := controllerutil.SetControllerReference(cd, cfgMap, r.scheme); err != nil {
+		cdLog.WithError(err).Error("error setting controller reference on config map")
+		return reconcile.Result{}, err
 	}
 
 	cdLog = cdLog.WithField("job", job.Name)
@@ -177,6 +355,9 @@ func (r *ReconcileClusterDeployment) Reconcile(request reconcile.Request) (recon
 			cdLog.Errorf("error creating job: %v", err)
 			return reconcile.Result{}, err
 		}
+		r.recorder.Eventf(cd, kapi.EventTypeNormal, "InstallJobCreated", "created install job %s", job.Name)
+		cd.Status.Conditions = setClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallJobCreatedCondition,
+			kapi.ConditionTrue, "JobCreated", "install job created")
 	} else if err != nil {
 		cdLog.Errorf("error getting job: %v", err)
 		return reconcile.Result{}, err
@@ -185,12 +366,37 @@ func (r *ReconcileClusterDeployment) Reconcile(request reconcile.Request) (recon
 		cdLog.Infof("conditions: %s", existingJob.Status.Conditions)
 		cd.Status.Installed = isSuccessful(existingJob)
 		cdLog.Infof("successful: %s", cd.Status.Installed)
+
+		switch {
+		case isSuccessful(existingJob):
+			cd.Status.Conditions = setClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallJobRunningCondition,
+				kapi.ConditionFalse, "JobCompleted", "install job completed successfully")
+			cd.Status.Conditions = setClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstalledCondition,
+				kapi.ConditionTrue, "InstallComplete", "cluster installation completed")
+			r.recorder.Eventf(cd, kapi.EventTypeNormal, "ClusterInstalled", "cluster installation completed")
+
+			if err := r.setAdminCredentialSecretRefs(cd, cdLog); err != nil {
+				cdLog.Errorf("error locating install credentials: %v", err)
+				return reconcile.Result{}, err
+			}
+		case isFailed(existingJob):
+			reason, message := r.lastInstallFailureReason(existingJob, cdLog)
+			cd.Status.Conditions = setClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallJobFailedCondition,
+				kapi.ConditionTrue, reason, message)
+			r.recorder.Eventf(cd, kapi.EventTypeWarning, "InstallJobFailed", "%s: %s", reason, message)
+		default:
+			cd.Status.Conditions = setClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallJobRunningCondition,
+				kapi.ConditionTrue, "JobRunning", "install job is running")
+		}
 	}
 
 	// Update cluster deployment status if changed:
 	if !reflect.DeepEqual(cd.Status, origCD.Status) {
 		cdLog.Infof("status has changed, updating cluster deployment")
-		err = r.Update(context.TODO(), cd)
+		newStatus := cd.Status
+		err = r.updateClusterDeploymentStatus(request.NamespacedName, cdLog, func(toUpdate *hivev1.ClusterDeployment) {
+			toUpdate.Status = newStatus
+		})
 		if err != nil {
 			cdLog.Errorf("error updating cluster deployment: %v", err)
 			return reconcile.Result{}, err
@@ -209,12 +415,12 @@ func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.Clu
 	uninstallJob, _, err := generateInstallerJob(fmt.Sprintf("%s-uninstall", cd.Name), cd, installerImage,
 		kapi.PullIfNotPresent, uninstall, nil, r.scheme)
 	if err != nil {
-		cdLog.Errorf("error generating uninstall job", err)
+		cdLog.WithError(err).Error("error generating uninstall job")
 		return reconcile.Result{}, err
 	}
 
 	if err := controllerutil.SetControllerReference(cd, uninstallJob, r.scheme); err != nil {
-		cdLog.Errorf("error setting controller reference on job", err)
+		cdLog.WithError(err).Error("error setting controller reference on job")
 		return reconcile.Result{}, err
 	}
 
@@ -236,23 +442,295 @@ func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.Clu
 	// Uninstall job exists, check it's status and if successful, remove the finalizer:
 	if isSuccessful(existingJob) {
 		cdLog.Infof("uninstall job successful, removing finalizer")
+		r.recorder.Eventf(cd, kapi.EventTypeNormal, "Deprovisioned", "cluster deprovision completed")
+		if err := r.setDeprovisionCondition(cd, cdLog, kapi.ConditionFalse, kapi.ConditionTrue); err != nil {
+			return reconcile.Result{}, err
+		}
 		return reconcile.Result{}, r.removeClusterDeploymentFinalizer(cd)
 	}
 
 	cdLog.Infof("uninstall job not yet successful")
+	if err := r.setDeprovisionCondition(cd, cdLog, kapi.ConditionTrue, kapi.ConditionFalse); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// setDeprovisionCondition updates the DeprovisionJobRunningCondition and DeprovisionedCondition on
+// the ClusterDeployment.
+func (r *ReconcileClusterDeployment) setDeprovisionCondition(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger, runningStatus, deprovisionedStatus kapi.ConditionStatus) error {
+	key := types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}
+	return r.updateClusterDeploymentStatus(key, cdLog, func(toUpdate *hivev1.ClusterDeployment) {
+		toUpdate.Status.Conditions = setClusterDeploymentCondition(toUpdate.Status.Conditions, hivev1.DeprovisionJobRunningCondition,
+			runningStatus, "JobStatus", "deprovision job status")
+		toUpdate.Status.Conditions = setClusterDeploymentCondition(toUpdate.Status.Conditions, hivev1.DeprovisionedCondition,
+			deprovisionedStatus, "JobStatus", "deprovision job status")
+	})
+}
+
+// isUninstallTriggered returns true if the ClusterDeployment carries the uninstall-trigger
+// annotation requesting a deprovision without deleting the object.
+func isUninstallTriggered(cd *hivev1.ClusterDeployment) bool {
+	return cd.Annotations[uninstallAnnotation] == "true"
+}
+
+// syncUninstallAnnotation runs the same uninstall Job logic as syncDeletedClusterDeployment, but is
+// re-entrant and idempotent: it never removes the deprovision finalizer, and once the uninstall Job
+// succeeds it deletes any lingering install Job/ConfigMap, records Status.Deprovisioned, and clears
+// the trigger annotation. Reconcile does not check Status.Deprovisioned, so the reconcile after the
+// annotation is cleared creates the install Job and ConfigMap again.
+func (r *ReconcileClusterDeployment) syncUninstallAnnotation(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
+	uninstall := true
+	uninstallJob, _, err := generateInstallerJob(fmt.Sprintf("%s-uninstall", cd.Name), cd, installerImage,
+		kapi.PullIfNotPresent, uninstall, nil, r.scheme)
+	if err != nil {
+		cdLog.WithError(err).Error("error generating uninstall job")
+		return reconcile.Result{}, err
+	}
+
+	if err := controllerutil.SetControllerReference(cd, uninstallJob, r.scheme); err != nil {
+		cdLog.WithError(err).Error("error setting controller reference on job")
+		return reconcile.Result{}, err
+	}
+
+	existingJob := &kbatch.Job{}
+	err = r.Get(context.TODO(), types.NamespacedName{Name: uninstallJob.Name, Namespace: uninstallJob.Namespace}, existingJob)
+	if err != nil && errors.IsNotFound(err) {
+		cdLog.Infof("creating uninstall job for uninstall annotation")
+		if err := r.Create(context.TODO(), uninstallJob); err != nil {
+			cdLog.Errorf("error creating uninstall job: %v", err)
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		cdLog.Errorf("error getting uninstall job: %v", err)
+		return reconcile.Result{}, err
+	}
+
+	if !isSuccessful(existingJob) {
+		cdLog.Infof("uninstall job not yet successful")
+		return reconcile.Result{}, nil
+	}
+
+	cdLog.Info("uninstall job successful, clearing uninstall annotation and removing install artifacts")
+	if err := r.removeInstallArtifacts(cd, cdLog); err != nil {
+		cdLog.Errorf("error removing install artifacts: %v", err)
+		return reconcile.Result{}, err
+	}
+
+	key := types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}
+	if err := r.updateClusterDeploymentStatus(key, cdLog, func(toUpdate *hivev1.ClusterDeployment) {
+		toUpdate.Status.Installed = false
+		toUpdate.Status.Deprovisioned = true
+		toUpdate.Status.Conditions = setClusterDeploymentCondition(toUpdate.Status.Conditions, hivev1.DeprovisionJobRunningCondition,
+			kapi.ConditionFalse, "JobCompleted", "deprovision job completed successfully")
+		toUpdate.Status.Conditions = setClusterDeploymentCondition(toUpdate.Status.Conditions, hivev1.DeprovisionedCondition,
+			kapi.ConditionTrue, "JobCompleted", "deprovision job completed successfully")
+	}); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.recorder.Eventf(cd, kapi.EventTypeNormal, "Deprovisioned", "cluster deprovision completed")
+
+	// Re-Get rather than reuse cd: updateClusterDeploymentStatus above already advanced its
+	// resourceVersion via Status().Update, so submitting the cd we read at the top of Reconcile
+	// here would always 409.
+	if err := r.updateClusterDeployment(key, cdLog, func(toUpdate *hivev1.ClusterDeployment) {
+		delete(toUpdate.Annotations, uninstallAnnotation)
+	}); err != nil {
+		cdLog.WithError(err).Error("error clearing uninstall annotation")
+		return reconcile.Result{}, err
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// removeInstallArtifacts deletes the install Job and ConfigMap, if present, so they are not mistaken
+// for a completed install once the cluster has been deprovisioned via the uninstall annotation.
+func (r *ReconcileClusterDeployment) removeInstallArtifacts(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	installJobName := fmt.Sprintf("%s-install", cd.Name)
+
+	job := &kbatch.Job{}
+	err := r.Get(context.TODO(), types.NamespacedName{Name: installJobName, Namespace: cd.Namespace}, job)
+	if err == nil {
+		if err := r.Delete(context.TODO(), job); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	cfgMap := &kapi.ConfigMap{}
+	err = r.Get(context.TODO(), types.NamespacedName{Name: installJobName, Namespace: cd.Namespace}, cfgMap)
+	if err == nil {
+		if err := r.Delete(context.TODO(), cfgMap); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	cdLog.Debug("install artifacts removed")
+	return nil
+}
+
+// setAdminCredentialSecretRefs locates the kubeconfig and kubeadmin password Secrets uploaded by the
+// installer's credential-uploader sidecar and records references to them on the ClusterDeployment
+// status. The installer's assigned cluster ID and infra ID live in metadata.json alongside those
+// credentials, but ClusterDeploymentStatus has nowhere to record them yet, so capturing those is
+// left for a follow-up once that field exists; this only wires up what ClusterDeploymentStatus can
+// already hold.
+func (r *ReconcileClusterDeployment) setAdminCredentialSecretRefs(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	kubeconfigSecretName := adminKubeconfigSecretName(cd.Name)
+	kubeconfigSecret := &kapi.Secret{}
+	err := r.Get(context.TODO(), types.NamespacedName{Name: kubeconfigSecretName, Namespace: cd.Namespace}, kubeconfigSecret)
+	if err != nil {
+		return err
+	}
+	cd.Status.AdminKubeconfigSecret = kapi.LocalObjectReference{Name: kubeconfigSecretName}
+
+	passwordSecretName := adminPasswordSecretName(cd.Name)
+	passwordSecret := &kapi.Secret{}
+	err = r.Get(context.TODO(), types.NamespacedName{Name: passwordSecretName, Namespace: cd.Namespace}, passwordSecret)
+	if err != nil {
+		return err
+	}
+	cd.Status.AdminPasswordSecret = kapi.LocalObjectReference{Name: passwordSecretName}
+
+	cdLog.WithField("kubeconfigSecret", kubeconfigSecretName).WithField("passwordSecret", passwordSecretName).
+		Info("recorded admin credential secret refs")
+	return nil
+}
+
+// ensureInstallerServiceAccount ensures the ServiceAccount and Role/RoleBinding the installer Job's
+// credential-uploader sidecar runs as exist in the ClusterDeployment's namespace, and returns the
+// ServiceAccount for generateInstallerJob to assign to the pod. Without this the uploader has no
+// identity the apiserver will authorize to create Secrets at all, so it fails every attempt and
+// setAdminCredentialSecretRefs retries forever waiting for Secrets that can never be created.
+func (r *ReconcileClusterDeployment) ensureInstallerServiceAccount(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*kapi.ServiceAccount, error) {
+	sa := &kapi.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      installerServiceAccountName,
+			Namespace: cd.Namespace,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cd, sa, r.scheme); err != nil {
+		return nil, err
+	}
+	existingSA := &kapi.ServiceAccount{}
+	err := r.Get(context.TODO(), types.NamespacedName{Name: sa.Name, Namespace: sa.Namespace}, existingSA)
+	if err != nil && errors.IsNotFound(err) {
+		cdLog.WithField("serviceAccount", sa.Name).Info("creating installer service account")
+		if err := r.Create(context.TODO(), sa); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	} else {
+		sa = existingSA
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      installerServiceAccountName,
+			Namespace: cd.Namespace,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"get", "create", "update"},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(cd, role, r.scheme); err != nil {
+		return nil, err
+	}
+	existingRole := &rbacv1.Role{}
+	err = r.Get(context.TODO(), types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, existingRole)
+	if err != nil && errors.IsNotFound(err) {
+		cdLog.WithField("role", role.Name).Info("creating installer role")
+		if err := r.Create(context.TODO(), role); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      installerServiceAccountName,
+			Namespace: cd.Namespace,
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.ServiceAccountKind, Name: sa.Name, Namespace: cd.Namespace},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cd, binding, r.scheme); err != nil {
+		return nil, err
+	}
+	existingBinding := &rbacv1.RoleBinding{}
+	err = r.Get(context.TODO(), types.NamespacedName{Name: binding.Name, Namespace: binding.Namespace}, existingBinding)
+	if err != nil && errors.IsNotFound(err) {
+		cdLog.WithField("roleBinding", binding.Name).Info("creating installer role binding")
+		if err := r.Create(context.TODO(), binding); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+func adminKubeconfigSecretName(clusterDeploymentName string) string {
+	return fmt.Sprintf("%s-admin-kubeconfig", clusterDeploymentName)
+}
+
+func adminPasswordSecretName(clusterDeploymentName string) string {
+	return fmt.Sprintf("%s-admin-password", clusterDeploymentName)
+}
+
+// updateClusterDeployment re-fetches the ClusterDeployment and applies mutate to it (its spec or
+// metadata, not its status) on each attempt, retrying on conflict so concurrent writers (webhooks,
+// other controllers, users) don't cause the reconciler to hot-loop. Use
+// updateClusterDeploymentStatus instead for status-only changes, so they go through the status
+// subresource rather than risking a stale read clobbering a concurrent spec/metadata write.
+func (r *ReconcileClusterDeployment) updateClusterDeployment(key types.NamespacedName, cdLog log.FieldLogger, mutate func(*hivev1.ClusterDeployment)) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cd := &hivev1.ClusterDeployment{}
+		if err := r.Get(context.TODO(), key, cd); err != nil {
+			return err
+		}
+		mutate(cd)
+		return r.Update(context.TODO(), cd)
+	})
+}
+
+// updateClusterDeploymentStatus re-fetches the ClusterDeployment and applies mutate to its Status on each
+// attempt, retrying on conflict so concurrent writers (webhooks, other controllers, users) don't cause
+// the reconciler to hot-loop.
+func (r *ReconcileClusterDeployment) updateClusterDeploymentStatus(key types.NamespacedName, cdLog log.FieldLogger, mutate func(*hivev1.ClusterDeployment)) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cd := &hivev1.ClusterDeployment{}
+		if err := r.Get(context.TODO(), key, cd); err != nil {
+			return err
+		}
+		mutate(cd)
+		return r.Status().Update(context.TODO(), cd)
+	})
+}
+
 func (r *ReconcileClusterDeployment) addClusterDeploymentFinalizer(cd *hivev1.ClusterDeployment) error {
-	cd = cd.DeepCopy()
-	AddFinalizer(cd, hivev1.FinalizerDeprovision)
-	return r.Update(context.TODO(), cd)
+	return finalizers.EnsureFinalizer(r.Client, cd, hivev1.FinalizerDeprovision)
 }
 
 func (r *ReconcileClusterDeployment) removeClusterDeploymentFinalizer(cd *hivev1.ClusterDeployment) error {
-	cd = cd.DeepCopy()
-	DeleteFinalizer(cd, hivev1.FinalizerDeprovision)
-	return r.Update(context.TODO(), cd)
+	return finalizers.RemoveFinalizer(r.Client, cd, hivev1.FinalizerDeprovision)
 }
 
 func generateInstallerJob(
@@ -303,8 +781,21 @@ func generateInstallerJob(
 
 	// Will be unused for uninstall jobs:
 	var cfgMap *kapi.ConfigMap
-	volumes := make([]kapi.Volume, 0, 1)
-	volumeMounts := make([]kapi.VolumeMount, 0, 1)
+	volumes := make([]kapi.Volume, 0, 2)
+	volumeMounts := make([]kapi.VolumeMount, 0, 2)
+
+	// Shared with the credential-uploader sidecar so it can pick up auth/kubeconfig and
+	// auth/kubeadmin-password once the installer writes them out.
+	volumeMounts = append(volumeMounts, kapi.VolumeMount{
+		Name:      "output",
+		MountPath: installerWorkDir,
+	})
+	volumes = append(volumes, kapi.Volume{
+		Name: "output",
+		VolumeSource: kapi.VolumeSource{
+			EmptyDir: &kapi.EmptyDirVolumeSource{},
+		},
+	})
 
 	if !uninstall {
 		cfgMap = &kapi.ConfigMap{
@@ -334,20 +825,61 @@ func generateInstallerJob(
 
 	}
 
-	containers := []kapi.Container{
-		{
-			Name:            "installer",
-			Image:           installerImage,
-			ImagePullPolicy: installerImagePullPolicy,
-			Env:             env,
-			VolumeMounts:    volumeMounts,
-			Command:         []string{"cat", "/home/user/installerinput/installconfig.yaml"},
-			//Command:      []string{"/home/user/installer/tectonic", "init", "--config", "/home/user/installerinput/installconfig.yaml"},
+	installerContainer := kapi.Container{
+		Name:            "installer",
+		Image:           installerImage,
+		ImagePullPolicy: installerImagePullPolicy,
+		Env:             env,
+		VolumeMounts:    volumeMounts,
+		Command: []string{
+			"/bin/sh", "-c",
+			// Touch installerDoneSentinel on the way out, success or failure, so the uploader
+			// sidecar doesn't wait forever on auth files a failed install will never produce.
+			fmt.Sprintf(`
+cp /home/user/installerinput/installconfig.yaml %[1]s/install-config.yaml && openshift-install create cluster --dir=%[1]s --log-level=debug
+rc=$?
+touch %[1]s/%[2]s
+exit $rc
+`, installerWorkDir, installerDoneSentinel),
 		},
 	}
 
 	if uninstall {
-		containers[0].Command = []string{"echo", "this would have been an uninstall"}
+		installerContainer.Command = []string{
+			"/bin/sh", "-c",
+			fmt.Sprintf("openshift-install destroy cluster --dir=%s --log-level=debug", installerWorkDir),
+		}
+	}
+
+	containers := []kapi.Container{installerContainer}
+
+	if !uninstall {
+		// Sidecar watches the shared work directory and uploads the generated kubeconfig and
+		// kubeadmin password as Secrets as soon as the installer writes them out.
+		containers = append(containers, kapi.Container{
+			Name:            "uploader",
+			Image:           uploaderImage,
+			ImagePullPolicy: installerImagePullPolicy,
+			VolumeMounts:    volumeMounts,
+			Command: []string{
+				"/bin/sh", "-c",
+				// Stop waiting as soon as installerDoneSentinel shows up without the auth files:
+				// that means the installer container already exited and failed, and the files it
+				// would have written are never coming, so looping further would only wedge the Job.
+				fmt.Sprintf(`
+set -e
+while [ ! -f %[1]s/auth/kubeconfig ] || [ ! -f %[1]s/auth/kubeadmin-password ]; do
+  if [ -f %[1]s/%[6]s ]; then
+    echo "installer exited without producing credentials" >&2
+    exit 1
+  fi
+  sleep 5
+done
+oc create secret generic %[2]s --from-file=%[3]s=%[1]s/auth/kubeconfig --dry-run -o yaml | oc apply -f -
+oc create secret generic %[4]s --from-file=%[5]s=%[1]s/auth/kubeadmin-password --dry-run -o yaml | oc apply -f -
+`, installerWorkDir, adminKubeconfigSecretName(cd.Name), kubeconfigSecretKey, adminPasswordSecretName(cd.Name), passwordSecretKey, installerDoneSentinel),
+			},
+		})
 	}
 
 	podSpec := kapi.PodSpec{
@@ -402,26 +934,63 @@ func isFailed(job *kbatch.Job) bool {
 	return getJobConditionStatus(job, kbatch.JobFailed) == kapi.ConditionTrue
 }
 
-// HasFinalizer returns true if the given object has the given finalizer
-func HasFinalizer(object metav1.Object, finalizer string) bool {
-	for _, f := range object.GetFinalizers() {
-		if f == finalizer {
-			return true
+// setClusterDeploymentCondition returns conditions with the given condition type set to status/
+// reason/message, adding it if not already present. LastTransitionTime is only bumped when the
+// status, reason, or message actually changed.
+func setClusterDeploymentCondition(
+	conditions []hivev1.ClusterDeploymentCondition,
+	conditionType hivev1.ClusterDeploymentConditionType,
+	status kapi.ConditionStatus,
+	reason string,
+	message string,
+) []hivev1.ClusterDeploymentCondition {
+	now := metav1.Now()
+	for i, cond := range conditions {
+		if cond.Type != conditionType {
+			continue
+		}
+		if cond.Status != status || cond.Reason != reason || cond.Message != message {
+			conditions[i].Status = status
+			conditions[i].Reason = reason
+			conditions[i].Message = message
+			conditions[i].LastTransitionTime = now
 		}
+		conditions[i].LastProbeTime = now
+		return conditions
 	}
-	return false
+	return append(conditions, hivev1.ClusterDeploymentCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+	})
 }
 
-// AddFinalizer adds a finalizer to the given object
-func AddFinalizer(object metav1.Object, finalizer string) {
-	finalizers := sets.NewString(object.GetFinalizers()...)
-	finalizers.Insert(finalizer)
-	object.SetFinalizers(finalizers.List())
-}
+// lastInstallFailureReason inspects the pods owned by the failed install job and surfaces the
+// terminated container's reason/message so users don't have to go digging through the Job's pods
+// themselves.
+func (r *ReconcileClusterDeployment) lastInstallFailureReason(job *kbatch.Job, cdLog log.FieldLogger) (reason, message string) {
+	reason, message = "JobFailed", "install job failed"
+
+	pods := &kapi.PodList{}
+	err := r.List(context.TODO(), &client.ListOptions{
+		Namespace:     job.Namespace,
+		LabelSelector: labels.SelectorFromSet(labels.Set{"job-name": job.Name}),
+	}, pods)
+	if err != nil {
+		cdLog.WithError(err).Warn("unable to list pods for failed install job")
+		return reason, message
+	}
 
-// DeleteFinalizer removes a finalizer from the given object
-func DeleteFinalizer(object metav1.Object, finalizer string) {
-	finalizers := sets.NewString(object.GetFinalizers()...)
-	finalizers.Delete(finalizer)
-	object.SetFinalizers(finalizers.List())
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+				return cs.State.Terminated.Reason, cs.State.Terminated.Message
+			}
+		}
+	}
+	return reason, message
 }
+
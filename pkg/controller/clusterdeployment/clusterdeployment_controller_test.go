@@ -0,0 +1,96 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdeployment
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// conflictingStatusWriter fails the first conflictsRemaining Status().Update calls with a 409
+// conflict before delegating to the wrapped client, so tests can prove a caller wrapped in
+// retry.RetryOnConflict actually converges instead of giving up or clobbering concurrent writes.
+type conflictingStatusWriter struct {
+	client.StatusWriter
+	conflictsRemaining *int
+}
+
+func (w *conflictingStatusWriter) Update(ctx context.Context, obj runtime.Object) error {
+	if *w.conflictsRemaining > 0 {
+		*w.conflictsRemaining--
+		return errors.NewConflict(schema.GroupResource{Resource: "clusterdeployments"}, "test", nil)
+	}
+	return w.StatusWriter.Update(ctx, obj)
+}
+
+type conflictingClient struct {
+	client.Client
+	conflictsRemaining *int
+}
+
+func (c *conflictingClient) Status() client.StatusWriter {
+	return &conflictingStatusWriter{StatusWriter: c.Client.Status(), conflictsRemaining: c.conflictsRemaining}
+}
+
+func TestUpdateClusterDeploymentStatusRetriesOnConflict(t *testing.T) {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "test-namespace"},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := hivev1.SchemeBuilder.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error registering scheme: %v", err)
+	}
+
+	conflicts := 2
+	fakeClient := fake.NewFakeClientWithScheme(scheme, cd)
+	r := &ReconcileClusterDeployment{
+		Client: &conflictingClient{Client: fakeClient, conflictsRemaining: &conflicts},
+	}
+
+	key := types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}
+	err := r.updateClusterDeploymentStatus(key, log.WithField("test", true), func(toUpdate *hivev1.ClusterDeployment) {
+		toUpdate.Status.Installed = true
+	})
+	if err != nil {
+		t.Fatalf("expected updateClusterDeploymentStatus to converge past injected conflicts, got error: %v", err)
+	}
+	if conflicts != 0 {
+		t.Fatalf("expected all injected conflicts to be consumed, %d remain", conflicts)
+	}
+
+	got := &hivev1.ClusterDeployment{}
+	if err := fakeClient.Get(context.TODO(), key, got); err != nil {
+		t.Fatalf("unexpected error fetching updated ClusterDeployment: %v", err)
+	}
+	if !got.Status.Installed {
+		t.Error("expected Status.Installed to be true after retrying past conflicts")
+	}
+}
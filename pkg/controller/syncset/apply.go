@@ -0,0 +1,289 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncset
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+
+	hiveresource "github.com/openshift/hive/pkg/resource"
+)
+
+// ApplyErrorKind classifies why an Apply or Patch call failed, derived from the underlying
+// Kubernetes API response. Surfacing this via ApplyFailureSyncCondition.Reason lets operators and
+// alerting tell a transient APIServer conflict from a permanent schema validation error without
+// parsing the error message.
+type ApplyErrorKind string
+
+const (
+	ApplyErrorConflict      ApplyErrorKind = "Conflict"
+	ApplyErrorInvalid       ApplyErrorKind = "Invalid"
+	ApplyErrorForbidden     ApplyErrorKind = "Forbidden"
+	ApplyErrorNotFound      ApplyErrorKind = "NotFound"
+	ApplyErrorServerTimeout ApplyErrorKind = "ServerTimeout"
+	ApplyErrorUnknown       ApplyErrorKind = "Unknown"
+)
+
+// ApplyError wraps a failure applying or patching a resource with enough structure for the
+// syncset controller to decide how to report and retry it. Retriable is false for failures that
+// will never succeed without a spec change (Invalid, Forbidden), so the controller can back off
+// hard instead of hotlooping on an unfixable manifest, while a Conflict can be requeued
+// immediately since it is expected to resolve itself.
+type ApplyError struct {
+	Kind      ApplyErrorKind
+	Resource  string
+	Reason    string
+	Retriable bool
+}
+
+func (e *ApplyError) Error() string {
+	return fmt.Sprintf("error applying resource %s: %s", e.Resource, e.Reason)
+}
+
+// classifyApplyError maps a dynamic client error for the named resource into a structured
+// ApplyError. Resource identifies the object for the error message (e.g. "ns/name (Kind)").
+func classifyApplyError(err error, resource string) error {
+	if err == nil {
+		return nil
+	}
+	kind := ApplyErrorUnknown
+	retriable := true
+	switch {
+	case apierrors.IsConflict(err):
+		kind = ApplyErrorConflict
+	case apierrors.IsInvalid(err):
+		kind = ApplyErrorInvalid
+		retriable = false
+	case apierrors.IsForbidden(err):
+		kind = ApplyErrorForbidden
+		retriable = false
+	case apierrors.IsNotFound(err):
+		kind = ApplyErrorNotFound
+	case apierrors.IsServerTimeout(err), apierrors.IsTimeout(err):
+		kind = ApplyErrorServerTimeout
+	}
+	return &ApplyError{Kind: kind, Resource: resource, Reason: err.Error(), Retriable: retriable}
+}
+
+// lastAppliedConfigAnnotation records the most recent desired configuration hive applied to an
+// object, in the same shape `kubectl apply` uses, so that a later apply can compute a three-way
+// merge against it rather than only diffing desired against whatever is live (which would
+// silently reintroduce fields a user removed from the SyncSet).
+const lastAppliedConfigAnnotation = "hive.openshift.io/last-applied-configuration"
+
+const (
+	applyResultCreated    hiveresource.ApplyResult = "created"
+	applyResultConfigured hiveresource.ApplyResult = "configured"
+	applyResultUnchanged  hiveresource.ApplyResult = "unchanged"
+)
+
+// dynamicApplier is an Applier that applies resources in-process using a dynamic client and a
+// three-way JSON merge patch, rather than shelling out to an external apply binary. Because
+// SyncSet resources arrive as arbitrary unstructured bytes, hive has no compiled-in Go type to
+// hand a strategic-merge schema for any given GVK (including CRDs), so it always computes a JSON
+// merge patch rather than a strategic merge patch.
+type dynamicApplier struct {
+	dynamicClient dynamic.Interface
+	restMapper    meta.RESTMapper
+	logger        log.FieldLogger
+}
+
+// newDynamicApplier returns an Applier backed by the given dynamic client and REST mapper.
+func newDynamicApplier(dynamicClient dynamic.Interface, restMapper meta.RESTMapper, logger log.FieldLogger) Applier {
+	return &dynamicApplier{
+		dynamicClient: dynamicClient,
+		restMapper:    restMapper,
+		logger:        logger,
+	}
+}
+
+// buildRESTMapper constructs a discovery-backed RESTMapper for the cluster identified by
+// kubeConfig, used to map a resource's Kind to its plural Resource for the dynamic client.
+func buildRESTMapper(kubeConfig []byte) (meta.RESTMapper, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+	if err != nil {
+		return nil, err
+	}
+	return restmapper.NewDiscoveryRESTMapper(groupResources), nil
+}
+
+func (a *dynamicApplier) resourceInterface(u *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := u.GroupVersionKind()
+	mapping, err := a.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("error mapping resource %s: %v", gvk.String(), err)
+	}
+	client := a.dynamicClient.Resource(mapping.Resource)
+	if u.GetNamespace() == "" {
+		return client, nil
+	}
+	return client.Namespace(u.GetNamespace()), nil
+}
+
+// Info decodes obj and returns the resource's identifying information.
+func (a *dynamicApplier) Info(obj []byte) (*hiveresource.Info, error) {
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(obj, &u.Object); err != nil {
+		return nil, fmt.Errorf("error decoding resource: %v", err)
+	}
+	gvk := u.GroupVersionKind()
+	mapping, err := a.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("error mapping resource %s: %v", gvk.String(), err)
+	}
+	return &hiveresource.Info{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Resource:   mapping.Resource.Resource,
+		Name:       u.GetName(),
+		Namespace:  u.GetNamespace(),
+	}, nil
+}
+
+// Apply creates obj if it does not exist, or else computes a three-way JSON merge patch between
+// the last-applied-configuration annotation, obj, and the live object, and patches the live
+// object with the result. The new last-applied-configuration is embedded in the same patch so it
+// stays in sync with what was actually applied.
+func (a *dynamicApplier) Apply(obj []byte) (hiveresource.ApplyResult, error) {
+	desired := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(obj, &desired.Object); err != nil {
+		return "", fmt.Errorf("error decoding resource: %v", err)
+	}
+	kind := desired.GetKind()
+	namespace := desired.GetNamespace()
+	name := desired.GetName()
+
+	ri, err := a.resourceInterface(desired)
+	if err != nil {
+		return "", err
+	}
+
+	desiredJSON, err := json.Marshal(desired.Object)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling resource %s/%s (%s): %v", namespace, name, kind, err)
+	}
+
+	resourceDesc := fmt.Sprintf("%s/%s (%s)", namespace, name, kind)
+
+	live, err := ri.Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		stamped := withLastAppliedConfig(desired, desiredJSON)
+		if _, err := ri.Create(stamped); err != nil {
+			return "", classifyApplyError(err, resourceDesc)
+		}
+		return applyResultCreated, nil
+	}
+	if err != nil {
+		return "", classifyApplyError(err, resourceDesc)
+	}
+
+	original := []byte("{}")
+	if lastApplied, ok := live.GetAnnotations()[lastAppliedConfigAnnotation]; ok {
+		original = []byte(lastApplied)
+	}
+
+	modifiedJSON, err := json.Marshal(withLastAppliedConfig(desired, desiredJSON).Object)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling resource %s/%s (%s): %v", namespace, name, kind, err)
+	}
+
+	currentJSON, err := json.Marshal(live.Object)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling live resource %s/%s (%s): %v", namespace, name, kind, err)
+	}
+
+	patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(original, modifiedJSON, currentJSON)
+	if err != nil {
+		return "", fmt.Errorf("error computing merge patch for resource %s/%s (%s): %v", namespace, name, kind, err)
+	}
+	if string(patch) == "{}" {
+		return applyResultUnchanged, nil
+	}
+	if _, err := ri.Patch(name, types.MergePatchType, patch); err != nil {
+		return "", classifyApplyError(err, resourceDesc)
+	}
+	return applyResultConfigured, nil
+}
+
+// withLastAppliedConfig returns a copy of u with lastAppliedConfigAnnotation set to
+// desiredJSON, the configuration hive is about to apply.
+func withLastAppliedConfig(u *unstructured.Unstructured, desiredJSON []byte) *unstructured.Unstructured {
+	stamped := u.DeepCopy()
+	annotations := stamped.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[lastAppliedConfigAnnotation] = string(desiredJSON)
+	stamped.SetAnnotations(annotations)
+	return stamped
+}
+
+// Patch applies a user-supplied SyncObjectPatch to the named object.
+func (a *dynamicApplier) Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return fmt.Errorf("error parsing apiVersion %s: %v", apiVersion, err)
+	}
+	mapping, err := a.restMapper.RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+	if err != nil {
+		return fmt.Errorf("error mapping resource %s/%s: %v", apiVersion, kind, err)
+	}
+	client := a.dynamicClient.Resource(mapping.Resource)
+	ri := dynamic.ResourceInterface(client)
+	if name.Namespace != "" {
+		ri = client.Namespace(name.Namespace)
+	}
+	// SyncObjectPatch.PatchType holds the short names "json", "merge" or "strategic" (the default),
+	// which the API server does not accept as a patch content type.
+	pt := types.PatchType(patchType)
+	switch patchType {
+	case "json":
+		pt = types.JSONPatchType
+	case "merge":
+		pt = types.MergePatchType
+	case "strategic", "":
+		pt = types.StrategicMergePatchType
+	}
+	resourceDesc := fmt.Sprintf("%s/%s (%s)", name.Namespace, name.Name, kind)
+	if _, err := ri.Patch(name.Name, pt, patch); err != nil {
+		return classifyApplyError(err, resourceDesc)
+	}
+	return nil
+}
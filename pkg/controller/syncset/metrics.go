@@ -0,0 +1,88 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncset
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultApplied    = "applied"
+	resultFailed     = "failed"
+	resultDeleted    = "deleted"
+	resultUnchanged  = "unchanged"
+	operationApply   = "apply"
+	operationPatch   = "patch"
+	operationDeleted = "delete"
+)
+
+var (
+	metricResourcesApplied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_syncset_apply_total",
+		Help: "Counter of SyncSet/SelectorSyncSet resources and patches processed, by operation and result.",
+	}, []string{"operation", "result"})
+
+	metricApplyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hive_syncset_apply_duration_seconds",
+		Help:    "Time to apply, patch or delete a single SyncSet/SelectorSyncSet resource, by operation and resource kind.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation", "kind", "apiVersion"})
+
+	metricClusterDeploymentsWithApplyFailures = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hive_clusterdeployments_with_syncset_apply_failures",
+		Help: "Number of ClusterDeployments currently reporting an ApplyFailure sync condition.",
+	})
+
+	metricSyncSetApplyFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_syncset_apply_failures",
+		Help: "Number of resources currently failing to apply, by ClusterDeployment and SyncSet.",
+	}, []string{"cluster_deployment", "syncset"})
+)
+
+func init() {
+	prometheus.MustRegister(metricResourcesApplied)
+	prometheus.MustRegister(metricApplyDuration)
+	prometheus.MustRegister(metricClusterDeploymentsWithApplyFailures)
+	prometheus.MustRegister(metricSyncSetApplyFailures)
+}
+
+// observeApplyDuration records how long an apply, patch or delete operation against a single
+// resource took.
+func observeApplyDuration(operation, kind, apiVersion string, start time.Time) {
+	metricApplyDuration.WithLabelValues(operation, kind, apiVersion).Observe(time.Since(start).Seconds())
+}
+
+var (
+	applyFailureClusterDeploymentsMu sync.Mutex
+	applyFailureClusterDeployments   = map[string]bool{}
+)
+
+// setClusterDeploymentApplyFailure records whether the named ClusterDeployment currently has an
+// apply failure and updates the fleet-wide gauge to the size of the resulting failing set.
+func setClusterDeploymentApplyFailure(clusterDeploymentName string, failing bool) {
+	applyFailureClusterDeploymentsMu.Lock()
+	defer applyFailureClusterDeploymentsMu.Unlock()
+	if failing {
+		applyFailureClusterDeployments[clusterDeploymentName] = true
+	} else {
+		delete(applyFailureClusterDeployments, clusterDeploymentName)
+	}
+	metricClusterDeploymentsWithApplyFailures.Set(float64(len(applyFailureClusterDeployments)))
+}
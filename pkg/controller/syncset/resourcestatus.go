@@ -0,0 +1,247 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncset
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1alpha1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+)
+
+// resourceStatusEventQueueSize bounds the buffer of pending spoke-resource-change notifications per
+// resourceStatusManager. The buffer is shared by every watched ClusterDeployment; enqueuing is a
+// best-effort, non-blocking send, and an event that arrives while the buffer is full is dropped.
+const resourceStatusEventQueueSize = 1024
+
+// resourceInformerResyncPeriod controls how often each per-kind informer does a full relist
+// against the spoke cluster, independent of the watch stream.
+const resourceInformerResyncPeriod = 10 * time.Minute
+
+// watchedResourceGVRs are the kinds hive watches on the spoke cluster to report live status for
+// resources a SyncSet or SelectorSyncSet applied, following the same one-informer-per-kind
+// pattern as ONAP's ResourceBundleState status-operator.
+var watchedResourceGVRs = []schema.GroupVersionResource{
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"},
+	{Group: "batch", Version: "v1", Resource: "jobs"},
+	{Version: "v1", Resource: "pods"},
+	{Version: "v1", Resource: "services"},
+	{Group: "extensions", Version: "v1beta1", Resource: "ingresses"},
+	{Version: "v1", Resource: "configmaps"},
+}
+
+// resourceStatusManager starts and caches one dynamic informer factory per ClusterDeployment,
+// each watching the kinds in watchedResourceGVRs filtered to objects carrying syncSetNameLabel,
+// and reduces their live state into a compact ResourceStatus on demand.
+type resourceStatusManager struct {
+	mu       sync.Mutex
+	watchers map[types.NamespacedName]*clusterResourceWatcher
+
+	// events carries a GenericEvent for the owning ClusterDeployment every time one of its watched
+	// spoke resources changes, so AddToManager can wire it into the controller's workqueue and
+	// live status is refreshed promptly rather than only when something unrelated reconciles the
+	// ClusterDeployment.
+	events chan event.GenericEvent
+}
+
+func newResourceStatusManager() *resourceStatusManager {
+	return &resourceStatusManager{
+		watchers: map[types.NamespacedName]*clusterResourceWatcher{},
+		events:   make(chan event.GenericEvent, resourceStatusEventQueueSize),
+	}
+}
+
+// Events returns the channel of GenericEvents requesting a reconcile of the ClusterDeployment whose
+// watched spoke resources changed. Callers should wire it into the controller with source.Channel.
+func (m *resourceStatusManager) Events() <-chan event.GenericEvent {
+	return m.events
+}
+
+// clusterResourceWatcher is the running informer set for a single ClusterDeployment.
+type clusterResourceWatcher struct {
+	informers map[schema.GroupVersionResource]cache.SharedIndexInformer
+	stopCh    chan struct{}
+}
+
+// EnsureWatching starts a dynamic informer factory for the given ClusterDeployment if one isn't
+// already running, watching every kind in watchedResourceGVRs for objects carrying
+// syncSetNameLabel, and returns it so its current cache can be snapshotted.
+func (m *resourceStatusManager) EnsureWatching(key types.NamespacedName, dynamicClient dynamic.Interface, cdLog log.FieldLogger) *clusterResourceWatcher {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if w, ok := m.watchers[key]; ok {
+		return w
+	}
+
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicClient, resourceInformerResyncPeriod, metav1.NamespaceAll,
+		func(opts *metav1.ListOptions) {
+			opts.LabelSelector = syncSetNameLabel
+		})
+
+	w := &clusterResourceWatcher{
+		informers: map[schema.GroupVersionResource]cache.SharedIndexInformer{},
+		stopCh:    make(chan struct{}),
+	}
+	enqueue := func(interface{}) { m.enqueueClusterDeployment(key) }
+	for _, gvr := range watchedResourceGVRs {
+		informer := factory.ForResource(gvr).Informer()
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueue,
+			UpdateFunc: func(_, new interface{}) { enqueue(new) },
+			DeleteFunc: enqueue,
+		})
+		w.informers[gvr] = informer
+	}
+	factory.Start(w.stopCh)
+	cdLog.Debug("started resource status informers for cluster deployment")
+
+	m.watchers[key] = w
+	return w
+}
+
+// enqueueClusterDeployment requests a reconcile of the ClusterDeployment identified by key. It
+// never blocks: the events channel is sized to absorb a burst of informer callbacks, and a send is
+// dropped when it's full. The informers' periodic resync (resourceInformerResyncPeriod) calls the
+// update handler for every cached object again, so a dropped ClusterDeployment is enqueued again
+// by the next resync at the latest.
+func (m *resourceStatusManager) enqueueClusterDeployment(key types.NamespacedName) {
+	cd := &hivev1.ClusterDeployment{}
+	cd.Name = key.Name
+	cd.Namespace = key.Namespace
+	select {
+	case m.events <- event.GenericEvent{Meta: cd, Object: cd}:
+	default:
+	}
+}
+
+// StopWatching tears down the informer factory for a ClusterDeployment that no longer exists.
+func (m *resourceStatusManager) StopWatching(key types.NamespacedName) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if w, ok := m.watchers[key]; ok {
+		close(w.stopCh)
+		delete(m.watchers, key)
+	}
+}
+
+// Snapshot reduces the current informer-cached state of every watched resource into a
+// ResourceStatus. Informers that have not yet completed their initial list/watch are skipped for
+// this round and are picked up on a later reconcile once synced.
+func (w *clusterResourceWatcher) Snapshot() []hiveintv1alpha1.ResourceStatus {
+	statuses := []hiveintv1alpha1.ResourceStatus{}
+	for gvr, informer := range w.informers {
+		if !informer.HasSynced() {
+			continue
+		}
+		for _, obj := range informer.GetStore().List() {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			statuses = append(statuses, reduceResourceStatus(gvr, u))
+		}
+	}
+	return statuses
+}
+
+// reduceResourceStatus reduces a single live object into the compact status hive reports back on
+// ClusterSync.
+func reduceResourceStatus(gvr schema.GroupVersionResource, u *unstructured.Unstructured) hiveintv1alpha1.ResourceStatus {
+	status := hiveintv1alpha1.ResourceStatus{
+		APIVersion:  gvr.GroupVersion().String(),
+		Kind:        u.GetKind(),
+		Namespace:   u.GetNamespace(),
+		Name:        u.GetName(),
+		SyncSetName: u.GetLabels()[syncSetNameLabel],
+	}
+
+	switch u.GetKind() {
+	case "Deployment", "StatefulSet", "DaemonSet":
+		replicas, _, _ := unstructured.NestedInt64(u.Object, "status", "replicas")
+		ready, _, _ := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+		status.Ready = replicas > 0 && ready >= replicas
+		status.Message = fmt.Sprintf("%d/%d replicas ready", ready, replicas)
+	case "Pod":
+		phase, _, _ := unstructured.NestedString(u.Object, "status", "phase")
+		status.Ready = phase == string(corev1.PodRunning) || phase == string(corev1.PodSucceeded)
+		status.Message = phase
+	case "Job":
+		succeeded, _, _ := unstructured.NestedInt64(u.Object, "status", "succeeded")
+		failed, _, _ := unstructured.NestedInt64(u.Object, "status", "failed")
+		status.Ready = succeeded > 0 && failed == 0
+		status.Message = fmt.Sprintf("%d succeeded, %d failed", succeeded, failed)
+	case "Service":
+		clusterIP, _, _ := unstructured.NestedString(u.Object, "spec", "clusterIP")
+		status.Ready = clusterIP != "" && clusterIP != corev1.ClusterIPNone
+		status.Message = clusterIP
+	case "Ingress":
+		lbIngress, _, _ := unstructured.NestedSlice(u.Object, "status", "loadBalancer", "ingress")
+		status.Ready = len(lbIngress) > 0
+		status.Message = fmt.Sprintf("%d load balancer ingress points", len(lbIngress))
+	case "ConfigMap":
+		status.Ready = true
+	default:
+		status.Ready = true
+	}
+
+	return status
+}
+
+// aggregateSyncSetReadiness groups ResourceStatuses by the SyncSet or SelectorSyncSet that owns
+// them, reporting each as ready only when every resource it applied reports ready.
+func aggregateSyncSetReadiness(statuses []hiveintv1alpha1.ResourceStatus) []hiveintv1alpha1.SyncSetResourcesReady {
+	readyBySyncSet := map[string]bool{}
+	seen := map[string]bool{}
+	for _, s := range statuses {
+		if s.SyncSetName == "" {
+			continue
+		}
+		if !seen[s.SyncSetName] {
+			readyBySyncSet[s.SyncSetName] = true
+			seen[s.SyncSetName] = true
+		}
+		if !s.Ready {
+			readyBySyncSet[s.SyncSetName] = false
+		}
+	}
+
+	result := make([]hiveintv1alpha1.SyncSetResourcesReady, 0, len(readyBySyncSet))
+	for name, ready := range readyBySyncSet {
+		result = append(result, hiveintv1alpha1.SyncSetResourcesReady{Name: name, Ready: ready})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
@@ -18,32 +18,41 @@ package syncset
 
 import (
 	"context"
-	"crypto/md5"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"time"
 
+	"github.com/ghodss/yaml"
 	log "github.com/sirupsen/logrus"
 
 	kapi "k8s.io/api/core/v1"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1alpha1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	hiveresource "github.com/openshift/hive/pkg/resource"
 )
@@ -57,6 +66,39 @@ const (
 	applyFailedReason           = "ApplyFailed"
 	deletionFailedReason        = "DeletionFailed"
 	reapplyInterval             = 2 * time.Hour
+
+	// applyErrorReason{Conflict,Invalid,Forbidden,NotFound,ServerTimeout} are the stable
+	// ApplyFailureSyncCondition.Reason values surfaced for each ApplyErrorKind, so operators (and
+	// alerting rules) can distinguish a transient conflict from a permanent validation failure
+	// without parsing the free-form message. applyFailedReason remains the fallback for an
+	// ApplyErrorUnknown or an error that never went through classifyApplyError.
+	applyErrorReasonConflict      = "ApplyConflict"
+	applyErrorReasonInvalid       = "ApplyInvalid"
+	applyErrorReasonForbidden     = "ApplyForbidden"
+	applyErrorReasonNotFound      = "ApplyNotFound"
+	applyErrorReasonServerTimeout = "ApplyServerTimeout"
+
+	// permanentApplyFailureReason is the PermanentApplyFailureSyncCondition.Reason set when an
+	// apply failure is classified as non-retriable, and notPermanentApplyFailureReason is set to
+	// clear that condition once a later apply succeeds or fails only transiently.
+	permanentApplyFailureReason    = "PermanentApplyFailure"
+	notPermanentApplyFailureReason = "NotPermanentApplyFailure"
+
+	// syncSetNameLabel is stamped onto every object hive applies to a target cluster, naming the
+	// SyncSet or SelectorSyncSet that owns it. It is used to group live resource status for
+	// display; see syncSetIDLabel for the label orphan pruning and cleanup key off of.
+	syncSetNameLabel = "hive.openshift.io/syncset-name"
+	// syncSetIDLabel is stamped onto every object hive applies to a target cluster with the value
+	// of syncSetID(namespace, name) for the owning SyncSet or SelectorSyncSet. Unlike
+	// syncSetNameLabel, it disambiguates SyncSets in different namespaces (or a SyncSet and a
+	// SelectorSyncSet) that happen to share a name, so orphan pruning and finalizer cleanup never
+	// pick up objects belonging to an unrelated SyncSet.
+	syncSetIDLabel = "hive.openshift.io/syncset-id"
+	// syncSetChecksumAnnotation is stamped onto every object hive applies to a target cluster with
+	// the sha256 checksum of the resource's desired content (including the labels above), so that
+	// drift and already-applied resources can be detected by reading the live object rather than
+	// only by comparing against ClusterSync.Status.
+	syncSetChecksumAnnotation = "hive.openshift.io/checksum"
 )
 
 // Applier knows how to Apply, Patch and return Info for []byte arrays describing objects and patches.
@@ -75,22 +117,18 @@ func Add(mgr manager.Manager) error {
 // NewReconciler returns a new reconcile.Reconciler
 func NewReconciler(mgr manager.Manager) reconcile.Reconciler {
 	r := &ReconcileSyncSet{
-		Client:               mgr.GetClient(),
-		scheme:               mgr.GetScheme(),
-		logger:               log.WithField("controller", controllerName),
-		applierBuilder:       applierBuilderFunc,
-		dynamicClientBuilder: controllerutils.BuildDynamicClientFromKubeconfig,
+		Client:                mgr.GetClient(),
+		scheme:                mgr.GetScheme(),
+		logger:                log.WithField("controller", controllerName),
+		applierBuilder:        newDynamicApplier,
+		dynamicClientBuilder:  controllerutils.BuildDynamicClientFromKubeconfig,
+		restMapperBuilder:     buildRESTMapper,
+		resourceStatusManager: newResourceStatusManager(),
 	}
 	r.hash = r.resourceHash
 	return r
 }
 
-// applierBuilderFunc returns an Applier which implements Info, Apply and Patch
-func applierBuilderFunc(kubeConfig []byte, logger log.FieldLogger) Applier {
-	var helper Applier = hiveresource.NewHelper(kubeConfig, logger)
-	return helper
-}
-
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
@@ -122,6 +160,14 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to resources the resourceStatusManager is watching on spoke clusters, so
+	// live status is refreshed promptly rather than only when something unrelated reconciles the
+	// ClusterDeployment.
+	err = c.Watch(&source.Channel{Source: reconciler.resourceStatusManager.Events()}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -175,10 +221,12 @@ type ReconcileSyncSet struct {
 	client.Client
 	scheme *runtime.Scheme
 
-	logger               log.FieldLogger
-	applierBuilder       func([]byte, log.FieldLogger) Applier
-	hash                 func([]byte) string
-	dynamicClientBuilder func(string) (dynamic.Interface, error)
+	logger                log.FieldLogger
+	applierBuilder        func(dynamic.Interface, meta.RESTMapper, log.FieldLogger) Applier
+	hash                  func([]byte) string
+	dynamicClientBuilder  func(string) (dynamic.Interface, error)
+	restMapperBuilder     func([]byte) (meta.RESTMapper, error)
+	resourceStatusManager *resourceStatusManager
 }
 
 // Reconcile lists SyncSets and SelectorSyncSets which apply to a ClusterDeployment object and applies resources and patches
@@ -191,7 +239,8 @@ func (r *ReconcileSyncSet) Reconcile(request reconcile.Request) (reconcile.Resul
 	err := r.Get(context.TODO(), request.NamespacedName, cd)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			// Object not found, return
+			// Object not found, stop watching its resource status and return
+			r.resourceStatusManager.StopWatching(request.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request
@@ -199,8 +248,9 @@ func (r *ReconcileSyncSet) Reconcile(request reconcile.Request) (reconcile.Resul
 		return reconcile.Result{}, err
 	}
 
-	// If the clusterdeployment is deleted, do not reconcile.
+	// If the clusterdeployment is deleted, stop watching its resource status and do not reconcile.
 	if cd.DeletionTimestamp != nil {
+		r.resourceStatusManager.StopWatching(request.NamespacedName)
 		return reconcile.Result{}, nil
 	}
 
@@ -215,11 +265,40 @@ func (r *ReconcileSyncSet) Reconcile(request reconcile.Request) (reconcile.Resul
 		return reconcile.Result{}, nil
 	}
 
-	origCD := cd
-	cd = cd.DeepCopy()
-
 	cdLog.Info("reconciling sync sets for cluster deployment")
 
+	clusterSync, err := r.getOrCreateClusterSync(cd, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Error("unable to get cluster sync")
+		return reconcile.Result{}, err
+	}
+	origClusterSync := clusterSync.DeepCopy()
+
+	lease, err := r.getOrCreateClusterSyncLease(cd, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Error("unable to get cluster sync lease")
+		return reconcile.Result{}, err
+	}
+	// The periodic-reapply sweep only runs on the reconcile that wins the lease roughly once per
+	// fleet-wide reapplyInterval, so concurrent reconciles of this same ClusterDeployment on other
+	// hive replicas don't all pay the cost of walking every resource/patch's own LastProbeTime in
+	// the same cycle (see dueForPeriodicReapply). Once a reconcile is allowed to sweep, each
+	// resource/patch still only actually re-applies if its own effective interval has elapsed,
+	// since SyncSets can override the fleet-wide default independently of each other.
+	periodicReapplyDue := false
+	elapsedSinceRenew := time.Duration(math.MaxInt64)
+	if lease.Spec.RenewTime != nil {
+		elapsedSinceRenew = time.Since(lease.Spec.RenewTime.Time)
+	}
+	if elapsedSinceRenew > reapplyInterval {
+		claimed, err := r.claimClusterSyncLease(lease, cdLog)
+		if err != nil {
+			cdLog.WithError(err).Error("unable to claim cluster sync lease")
+			return reconcile.Result{}, err
+		}
+		periodicReapplyDue = claimed
+	}
+
 	// get all sync sets that apply to cd
 	syncSets, err := r.getRelatedSyncSets(cd)
 	if err != nil {
@@ -259,12 +338,25 @@ func (r *ReconcileSyncSet) Reconcile(request reconcile.Request) (reconcile.Resul
 		cdLog.WithError(err).Error("unable to build dynamic client")
 		return reconcile.Result{}, err
 	}
+	restMapper, err := r.restMapperBuilder(kubeConfig)
+	if err != nil {
+		cdLog.WithError(err).Error("unable to build REST mapper")
+		return reconcile.Result{}, err
+	}
+	applier := r.applierBuilder(dynamicClient, restMapper, cdLog)
 
 	// Track the first error we hit during reconcile. This allows us to keep processing
 	// objects even if one encounters an error, but we always want to return an error to
 	// the controllers so they will re-try.
 	var firstSyncSetErr error
 
+	// touchedSyncSetNames/touchedSelectorSyncSetNames and removedSyncSetNames record which
+	// SyncSetObjectStatus entries this reconcile actually modified or removed, so that
+	// updateClusterSyncStatus can re-apply only those entries on top of a freshly re-fetched
+	// ClusterSync if it hits a conflict, rather than clobbering a sibling SyncSet's concurrent
+	// status write with our possibly-stale view of the whole list.
+	var touchedSyncSetNames, touchedSelectorSyncSetNames, removedSyncSetNames []string
+
 	for _, syncSet := range syncSets {
 		ssLog := cdLog.WithFields(log.Fields{"syncSet": syncSet.Name})
 
@@ -272,14 +364,15 @@ func (r *ReconcileSyncSet) Reconcile(request reconcile.Request) (reconcile.Resul
 			if controllerutils.HasFinalizer(&syncSet, hivev1.FinalizerSyncSetCleanup) {
 				// Delete syncset resources
 				if syncSet.Spec.ResourceDeletionPolicy != hivev1.OrphanResourceDeletionPolicy {
-					syncSetStatus := findSyncSetStatus(syncSet.Name, cd.Status.SyncSetStatus)
-					err := r.deleteSyncSetResources(syncSet.Spec.Resources, syncSetStatus, dynamicClient, ssLog)
+					syncSetStatus := findSyncSetStatus(syncSet.Name, clusterSync.Status.SyncSets)
+					err := r.deleteSyncSetResources(syncSet.Spec.Resources, syncSetStatus, applier, dynamicClient, syncSetID(syncSet.Namespace, syncSet.Name), ssLog)
 					if err != nil {
 						ssLog.WithError(err).Error("unable to cleanup syncset resources")
 					}
 				}
-				// Remove syncset status from clusterdeployment
-				cd.Status.SyncSetStatus = removeSyncSetObjectStatus(cd.Status.SyncSetStatus, syncSet.Name)
+				// Remove syncset status from ClusterSync
+				clusterSync.Status.SyncSets = removeSyncSetObjectStatus(clusterSync.Status.SyncSets, syncSet.Name)
+				removedSyncSetNames = append(removedSyncSetNames, syncSet.Name)
 				if err := r.removeSyncSetFinalizer(&syncSet); err != nil {
 					ssLog.WithError(err).Error("unable to remove finalizer")
 					return reconcile.Result{}, err
@@ -298,28 +391,38 @@ func (r *ReconcileSyncSet) Reconcile(request reconcile.Request) (reconcile.Resul
 			continue
 		}
 
+		syncSetStatus := findSyncSetStatus(syncSet.Name, clusterSync.Status.SyncSets)
+		syncSetStatus.Conditions = setSuspendedSyncCondition(syncSetStatus.Conditions, syncSet.Spec.Suspend, syncSet.Generation)
+		if syncSet.Spec.Suspend {
+			ssLog.Debug("syncset is suspended, skipping apply")
+			syncSetStatus.ObservedGeneration = syncSet.Generation
+			clusterSync.Status.SyncSets = appendOrUpdateSyncSetObjectStatus(clusterSync.Status.SyncSets, syncSetStatus)
+			touchedSyncSetNames = append(touchedSyncSetNames, syncSet.Name)
+			continue
+		}
+
 		ssLog.Debug("applying sync set")
 
-		syncSetStatus := findSyncSetStatus(syncSet.Name, cd.Status.SyncSetStatus)
-		applier := r.applierBuilder(kubeConfig, cdLog)
-		err = r.applySyncSetResources(syncSet.Spec.ResourceApplyMode, syncSet.Spec.Resources, dynamicClient, applier, &syncSetStatus, ssLog)
+		err = r.applySyncSetResources(syncSet.Name, syncSetID(syncSet.Namespace, syncSet.Name), syncSet.Spec.ResourceApplyMode, syncSet.Spec.PruneMode, syncSet.Spec.Resources, dynamicClient, applier, &syncSetStatus, periodicReapplyDue, syncSet.Spec.ReapplyInterval, syncSet.Generation, ssLog)
 		if err != nil {
 			ssLog.WithError(err).Error("unable to apply sync set resources")
 			// skip applying sync set patches when resources could not be applied
-			cd.Status.SyncSetStatus = appendOrUpdateSyncSetObjectStatus(cd.Status.SyncSetStatus, syncSetStatus)
+			clusterSync.Status.SyncSets = appendOrUpdateSyncSetObjectStatus(clusterSync.Status.SyncSets, syncSetStatus)
+			touchedSyncSetNames = append(touchedSyncSetNames, syncSet.Name)
 			if firstSyncSetErr == nil {
 				firstSyncSetErr = err
 			}
 			continue
 		}
-		err = r.applySyncSetPatches(syncSet.Spec.Patches, kubeConfig, &syncSetStatus, ssLog)
+		err = r.applySyncSetPatches(syncSet.Spec.Patches, applier, &syncSetStatus, periodicReapplyDue, syncSet.Spec.ReapplyInterval, syncSet.Generation, ssLog)
 		if err != nil {
 			ssLog.WithError(err).Error("unable to apply sync set patches")
 			if firstSyncSetErr == nil {
 				firstSyncSetErr = err
 			}
 		}
-		cd.Status.SyncSetStatus = appendOrUpdateSyncSetObjectStatus(cd.Status.SyncSetStatus, syncSetStatus)
+		clusterSync.Status.SyncSets = appendOrUpdateSyncSetObjectStatus(clusterSync.Status.SyncSets, syncSetStatus)
+		touchedSyncSetNames = append(touchedSyncSetNames, syncSet.Name)
 	}
 
 	for _, selectorSyncSet := range selectorSyncSets {
@@ -329,14 +432,15 @@ func (r *ReconcileSyncSet) Reconcile(request reconcile.Request) (reconcile.Resul
 			if controllerutils.HasFinalizer(&selectorSyncSet, hivev1.FinalizerSyncSetCleanup) {
 				// Delete syncset resources
 				if selectorSyncSet.Spec.ResourceDeletionPolicy != hivev1.OrphanResourceDeletionPolicy {
-					syncSetStatus := findSyncSetStatus(selectorSyncSet.Name, cd.Status.SyncSetStatus)
-					err := r.deleteSyncSetResources(selectorSyncSet.Spec.Resources, syncSetStatus, dynamicClient, ssLog)
+					syncSetStatus := findSyncSetStatus(selectorSyncSet.Name, clusterSync.Status.SyncSets)
+					err := r.deleteSyncSetResources(selectorSyncSet.Spec.Resources, syncSetStatus, applier, dynamicClient, syncSetID("", selectorSyncSet.Name), ssLog)
 					if err != nil {
 						ssLog.WithError(err).Error("unable to cleanup syncset resources")
 					}
 				}
-				// Remove syncset status from clusterdeployment
-				cd.Status.SyncSetStatus = removeSyncSetObjectStatus(cd.Status.SyncSetStatus, selectorSyncSet.Name)
+				// Remove syncset status from ClusterSync
+				clusterSync.Status.SyncSets = removeSyncSetObjectStatus(clusterSync.Status.SyncSets, selectorSyncSet.Name)
+				removedSyncSetNames = append(removedSyncSetNames, selectorSyncSet.Name)
 				if err := r.removeSelectorSyncSetFinalizer(&selectorSyncSet); err != nil {
 					ssLog.WithError(err).Error("unable to remove finalizer")
 					return reconcile.Result{}, err
@@ -355,55 +459,153 @@ func (r *ReconcileSyncSet) Reconcile(request reconcile.Request) (reconcile.Resul
 			continue
 		}
 
+		syncSetStatus := findSyncSetStatus(selectorSyncSet.Name, clusterSync.Status.SelectorSyncSets)
+		syncSetStatus.Conditions = setSuspendedSyncCondition(syncSetStatus.Conditions, selectorSyncSet.Spec.Suspend, selectorSyncSet.Generation)
+		if selectorSyncSet.Spec.Suspend {
+			ssLog.Debug("selector syncset is suspended, skipping apply")
+			syncSetStatus.ObservedGeneration = selectorSyncSet.Generation
+			clusterSync.Status.SelectorSyncSets = appendOrUpdateSyncSetObjectStatus(clusterSync.Status.SelectorSyncSets, syncSetStatus)
+			touchedSelectorSyncSetNames = append(touchedSelectorSyncSetNames, selectorSyncSet.Name)
+			continue
+		}
+
 		ssLog.Debug("applying selector sync set")
 
-		syncSetStatus := findSyncSetStatus(selectorSyncSet.Name, cd.Status.SelectorSyncSetStatus)
-		applier := r.applierBuilder(kubeConfig, cdLog)
-		err = r.applySyncSetResources(selectorSyncSet.Spec.ResourceApplyMode, selectorSyncSet.Spec.Resources, dynamicClient, applier, &syncSetStatus, ssLog)
+		err = r.applySyncSetResources(selectorSyncSet.Name, syncSetID("", selectorSyncSet.Name), selectorSyncSet.Spec.ResourceApplyMode, selectorSyncSet.Spec.PruneMode, selectorSyncSet.Spec.Resources, dynamicClient, applier, &syncSetStatus, periodicReapplyDue, selectorSyncSet.Spec.ReapplyInterval, selectorSyncSet.Generation, ssLog)
 		if err != nil {
 			ssLog.WithError(err).Error("unable to apply selector sync set resources")
 			// skip applying selector sync set patches when resources could not be applied
-			cd.Status.SelectorSyncSetStatus = appendOrUpdateSyncSetObjectStatus(cd.Status.SelectorSyncSetStatus, syncSetStatus)
+			clusterSync.Status.SelectorSyncSets = appendOrUpdateSyncSetObjectStatus(clusterSync.Status.SelectorSyncSets, syncSetStatus)
+			touchedSelectorSyncSetNames = append(touchedSelectorSyncSetNames, selectorSyncSet.Name)
 			if firstSyncSetErr == nil {
 				firstSyncSetErr = err
 			}
 			continue
 		}
-		err = r.applySyncSetPatches(selectorSyncSet.Spec.Patches, kubeConfig, &syncSetStatus, ssLog)
+		err = r.applySyncSetPatches(selectorSyncSet.Spec.Patches, applier, &syncSetStatus, periodicReapplyDue, selectorSyncSet.Spec.ReapplyInterval, selectorSyncSet.Generation, ssLog)
 		if err != nil {
 			ssLog.WithError(err).Error("unable to apply selector sync set patches")
 			if firstSyncSetErr == nil {
 				firstSyncSetErr = err
 			}
 		}
-		cd.Status.SelectorSyncSetStatus = appendOrUpdateSyncSetObjectStatus(cd.Status.SelectorSyncSetStatus, syncSetStatus)
+		clusterSync.Status.SelectorSyncSets = appendOrUpdateSyncSetObjectStatus(clusterSync.Status.SelectorSyncSets, syncSetStatus)
+		touchedSelectorSyncSetNames = append(touchedSelectorSyncSetNames, selectorSyncSet.Name)
 	}
 
-	err = r.updateClusterDeploymentStatus(cd, origCD, cdLog)
+	watcher := r.resourceStatusManager.EnsureWatching(request.NamespacedName, dynamicClient, cdLog)
+	clusterSync.Status.ResourceStatuses = watcher.Snapshot()
+	clusterSync.Status.SyncSetResourcesReady = aggregateSyncSetReadiness(clusterSync.Status.ResourceStatuses)
+
+	hasFailure := r.recordApplyFailureMetrics(cd, clusterSync)
+	clusterSync.Status.Conditions = setClusterSyncFailedCondition(clusterSync.Status.Conditions, hasFailure, firstSyncSetErr)
+
+	err = r.updateClusterSyncStatus(clusterSync, origClusterSync, touchedSyncSetNames, touchedSelectorSyncSetNames, removedSyncSetNames, cdLog)
 	if err != nil {
-		cdLog.WithError(err).Errorf("error updating cluster deployment status")
+		cdLog.WithError(err).Errorf("error updating cluster sync status")
 		return reconcile.Result{}, err
 	}
 
+	// A Conflict is expected to clear itself on the next apply attempt, so requeue quickly with
+	// jitter rather than returning the error and subjecting it to the workqueue's growing
+	// exponential backoff, which would leave the resource out of sync longer than necessary.
+	if ae, ok := firstSyncSetErr.(*ApplyError); ok && ae.Kind == ApplyErrorConflict {
+		cdLog.WithError(firstSyncSetErr).Info("conflict applying sync set resources, requeueing with jitter")
+		return reconcile.Result{RequeueAfter: wait.Jitter(2*time.Second, 1.0)}, nil
+	}
+
 	cdLog.WithError(err).Info("done reconciling sync sets for cluster deployment")
 	return reconcile.Result{}, firstSyncSetErr
 }
 
+// recordApplyFailureMetrics updates the per-ClusterDeployment/SyncSet apply failure gauge from the
+// freshly computed ClusterSync status so operators can alert on stuck SyncSets without parsing
+// SyncSetStatus lists off of every ClusterDeployment. It returns whether any SyncSet or
+// SelectorSyncSet for this cluster is currently failing to apply. Conditions whose
+// ObservedGeneration lags the SyncSet's current generation are ignored, since they reflect a prior
+// spec rather than the one in effect now.
+func (r *ReconcileSyncSet) recordApplyFailureMetrics(cd *hivev1.ClusterDeployment, clusterSync *hiveintv1alpha1.ClusterSync) bool {
+	hasFailure := false
+	for _, statusList := range [][]hivev1.SyncSetObjectStatus{clusterSync.Status.SyncSets, clusterSync.Status.SelectorSyncSets} {
+		for _, ssStatus := range statusList {
+			failures := 0
+			for _, res := range ssStatus.Resources {
+				if res.ObservedGeneration < ssStatus.ObservedGeneration {
+					continue
+				}
+				if cond := controllerutils.FindSyncCondition(res.Conditions, hivev1.ApplyFailureSyncCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+					failures++
+				}
+			}
+			metricSyncSetApplyFailures.WithLabelValues(cd.Name, ssStatus.Name).Set(float64(failures))
+			if failures > 0 {
+				hasFailure = true
+			}
+		}
+	}
+	setClusterDeploymentApplyFailure(cd.Name, hasFailure)
+	return hasFailure
+}
+
+// setClusterSyncFailedCondition sets the overall ClusterSyncFailed condition summarizing whether
+// any SyncSet or SelectorSyncSet currently failed to apply to this cluster.
+func setClusterSyncFailedCondition(conditions []hiveintv1alpha1.ClusterSyncCondition, hasFailure bool, reconcileErr error) []hiveintv1alpha1.ClusterSyncCondition {
+	status := corev1.ConditionFalse
+	reason := "SyncSetApplySuccess"
+	message := "All SyncSets and SelectorSyncSets have been applied successfully"
+	if hasFailure || reconcileErr != nil {
+		status = corev1.ConditionTrue
+		reason = applyFailedReason
+		message = "One or more SyncSets or SelectorSyncSets failed to apply"
+		if reconcileErr != nil {
+			message = fmt.Sprintf("One or more SyncSets or SelectorSyncSets failed to apply: %v", reconcileErr)
+		}
+	}
+	for i, cond := range conditions {
+		if cond.Type != hiveintv1alpha1.ClusterSyncFailed {
+			continue
+		}
+		if cond.Status != status || cond.Reason != reason || cond.Message != message {
+			now := metav1.Now()
+			conditions[i].Status = status
+			conditions[i].Reason = reason
+			conditions[i].Message = message
+			conditions[i].LastProbeTime = now
+			if cond.Status != status {
+				conditions[i].LastTransitionTime = now
+			}
+		}
+		return conditions
+	}
+	now := metav1.Now()
+	return append(conditions, hiveintv1alpha1.ClusterSyncCondition{
+		Type:               hiveintv1alpha1.ClusterSyncFailed,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+	})
+}
+
 // applySyncSetResources evaluates resource objects from RawExtension and applies them to the cluster identified by kubeConfig
-func (r *ReconcileSyncSet) applySyncSetResources(applyMode hivev1.SyncSetResourceApplyMode, ssResources []runtime.RawExtension, dynamicClient dynamic.Interface, h Applier, syncSetStatus *hivev1.SyncSetObjectStatus, ssLog log.FieldLogger) error {
+func (r *ReconcileSyncSet) applySyncSetResources(syncSetName, syncSetID string, applyMode hivev1.SyncSetResourceApplyMode, pruneMode hivev1.SyncSetResourcePruneMode, ssResources []runtime.RawExtension, dynamicClient dynamic.Interface, h Applier, syncSetStatus *hivev1.SyncSetObjectStatus, periodicReapplyDue bool, reapplyIntervalOverride *metav1.Duration, generation int64, ssLog log.FieldLogger) error {
+	applyOnce := applyMode == hivev1.ApplyOnceResourceApplyMode
+	effectiveInterval := effectiveReapplyInterval(reapplyIntervalOverride)
 	// determine if we can gather info for all resources
 	infos := []hiveresource.Info{}
 	for i, resource := range ssResources {
 		info, err := h.Info(resource.Raw)
 		if err != nil {
 			// error gathering resource info, set UnknownObjectSyncCondition within syncSetStatus conditions
-			syncSetStatus.Conditions = r.setUnknownObjectSyncCondition(syncSetStatus.Conditions, err, i)
+			syncSetStatus.Conditions = r.setUnknownObjectSyncCondition(syncSetStatus.Conditions, err, i, generation)
 			return err
 		}
 		infos = append(infos, *info)
 	}
 
-	syncSetStatus.Conditions = r.setUnknownObjectSyncCondition(syncSetStatus.Conditions, nil, 0)
+	syncSetStatus.Conditions = r.setUnknownObjectSyncCondition(syncSetStatus.Conditions, nil, 0, generation)
+	syncSetStatus.ObservedGeneration = generation
 	syncStatusList := []hivev1.SyncStatus{}
 
 	var applyErr error
@@ -418,6 +620,7 @@ func (r *ReconcileSyncSet) applySyncSetResources(applyMode hivev1.SyncSetResourc
 		}
 
 		var resourceSyncConditions []hivev1.SyncCondition
+		var observedGeneration int64
 
 		// determine if resource is found, different or should be reapplied based on last probe time
 		found := false
@@ -429,8 +632,9 @@ func (r *ReconcileSyncSet) applySyncSetResources(applyMode hivev1.SyncSetResourc
 				rss.APIVersion == resourceSyncStatus.APIVersion &&
 				rss.Kind == resourceSyncStatus.Kind {
 				resourceSyncConditions = rss.Conditions
+				observedGeneration = rss.ObservedGeneration
 				found = true
-				if rss.Hash != resourceSyncStatus.Hash {
+				if !applyOnce && rss.Hash != resourceSyncStatus.Hash {
 					ssLog.Debugf("Resource %s/%s (%s) has changed, will re-apply", infos[i].Namespace, infos[i].Name, infos[i].Kind)
 					different = true
 					break
@@ -445,31 +649,54 @@ func (r *ReconcileSyncSet) applySyncSetResources(applyMode hivev1.SyncSetResourc
 					}
 				}
 
-				// re-apply if two hours have passed since LastProbeTime
-				if applySuccessCondition := controllerutils.FindSyncCondition(rss.Conditions, hivev1.ApplySuccessSyncCondition); applySuccessCondition != nil {
-					since := time.Since(applySuccessCondition.LastProbeTime.Time)
-					if since > reapplyInterval {
-						ssLog.Debugf("It has been %v since resource %s/%s (%s) was last applied, will re-apply", since, infos[i].Namespace, infos[i].Name, infos[i].Kind)
-						shouldReApply = true
-					}
+				// re-apply once this resource's own effective reapply interval has elapsed since
+				// it was last successfully applied, but only on a reconcile that won the cluster
+				// sync lease this cycle (periodicReapplyDue); see claimClusterSyncLease.
+				if !applyOnce && periodicReapplyDue && dueForPeriodicReapply(rss.Conditions, effectiveInterval) {
+					ssLog.Debugf("resource %s/%s (%s) is due for periodic reapply", infos[i].Namespace, infos[i].Name, infos[i].Kind)
+					shouldReApply = true
 				}
 				break
 			}
 		}
 
-		if !found || different || shouldReApply {
+		needsApply := !found || different || shouldReApply
+		if needsApply && !found {
+			// The ClusterSync status we rely on above may have been lost or truncated.
+			// Before assuming this resource is new, check whether the live object already
+			// carries the checksum we're about to apply.
+			if liveChecksum, ok := r.liveResourceChecksum(dynamicClient, infos[i]); ok {
+				if checksum, _, err := canonicalResourceChecksum(resource.Raw, syncSetName, syncSetID); err == nil && liveChecksum == checksum {
+					ssLog.Debugf("resource %s/%s (%s) already has the desired checksum in-cluster, will not re-apply", infos[i].Namespace, infos[i].Name, infos[i].Kind)
+					needsApply = false
+				}
+			}
+		}
+
+		if needsApply {
 			ssLog.Debugf("applying resource: %s/%s (%s)", infos[i].Namespace, infos[i].Name, infos[i].Kind)
 			var result hiveresource.ApplyResult
-			result, applyErr = h.Apply(resource.Raw)
-			resourceSyncStatus.Conditions = r.setApplySyncConditions(resourceSyncConditions, applyErr)
+			var stamped []byte
+			stamped, applyErr = stampSyncSetMetadata(resource.Raw, syncSetName, syncSetID)
+			if applyErr == nil {
+				start := time.Now()
+				result, applyErr = h.Apply(stamped)
+				observeApplyDuration(operationApply, infos[i].Kind, infos[i].APIVersion, start)
+			}
+			resourceSyncStatus.Conditions = r.setApplySyncConditions(resourceSyncConditions, applyErr, generation)
+			resourceSyncStatus.ObservedGeneration = generation
 			if applyErr != nil {
 				ssLog.WithError(applyErr).Errorf("error applying resource %s/%s (%s)", infos[i].Namespace, infos[i].Name, infos[i].Kind)
+				metricResourcesApplied.WithLabelValues(operationApply, resultFailed).Inc()
 			} else {
 				ssLog.Debug("resource %s/%s (%s): %s", infos[i].Namespace, infos[i].Name, infos[i].Kind, result)
+				metricResourcesApplied.WithLabelValues(operationApply, resultApplied).Inc()
 			}
 		} else {
 			ssLog.Debugf("resource %s/%s (%s) has not changed, will not apply", infos[i].Namespace, infos[i].Name, infos[i].Kind)
 			resourceSyncStatus.Conditions = resourceSyncConditions
+			resourceSyncStatus.ObservedGeneration = observedGeneration
+			metricResourcesApplied.WithLabelValues(operationApply, resultUnchanged).Inc()
 		}
 
 		syncStatusList = append(syncStatusList, resourceSyncStatus)
@@ -481,7 +708,7 @@ func (r *ReconcileSyncSet) applySyncSetResources(applyMode hivev1.SyncSetResourc
 	}
 
 	var delErr error
-	syncSetStatus.Resources, delErr = r.reconcileDeletedSyncSetResources(applyMode, dynamicClient, syncSetStatus.Resources, syncStatusList, applyErr, ssLog)
+	syncSetStatus.Resources, delErr = r.reconcileDeletedSyncSetResources(applyMode, pruneMode, dynamicClient, syncSetID, syncSetStatus.Resources, syncStatusList, applyErr, ssLog)
 	if delErr != nil {
 		ssLog.WithError(delErr).Error("error reconciling syncset resources")
 		return delErr
@@ -499,9 +726,9 @@ func (r *ReconcileSyncSet) applySyncSetResources(applyMode hivev1.SyncSetResourc
 	return nil
 }
 
-// applySyncSetPatches applies patches to cluster identified by kubeConfig
-func (r *ReconcileSyncSet) applySyncSetPatches(ssPatches []hivev1.SyncObjectPatch, kubeConfig []byte, syncSetStatus *hivev1.SyncSetObjectStatus, ssLog log.FieldLogger) error {
-	h := r.applierBuilder(kubeConfig, r.logger)
+// applySyncSetPatches applies patches to the cluster using h
+func (r *ReconcileSyncSet) applySyncSetPatches(ssPatches []hivev1.SyncObjectPatch, h Applier, syncSetStatus *hivev1.SyncSetObjectStatus, periodicReapplyDue bool, reapplyIntervalOverride *metav1.Duration, generation int64, ssLog log.FieldLogger) error {
+	effectiveInterval := effectiveReapplyInterval(reapplyIntervalOverride)
 
 	for _, ssPatch := range ssPatches {
 		patchSyncStatus := hivev1.SyncStatus{
@@ -537,15 +764,13 @@ func (r *ReconcileSyncSet) applySyncSetPatches(ssPatches []hivev1.SyncObjectPatc
 					}
 				}
 
-				// re-apply if two hours have passed since LastProbeTime and patch apply mode is not apply once
-				if ssPatch.ApplyMode != hivev1.ApplyOncePatchApplyMode {
-					if applySuccessCondition := controllerutils.FindSyncCondition(pss.Conditions, hivev1.ApplySuccessSyncCondition); applySuccessCondition != nil {
-						since := time.Since(applySuccessCondition.LastProbeTime.Time)
-						if since > reapplyInterval {
-							ssLog.Debugf("It has been %v since resource %s/%s (%s) was last applied, will re-apply", since, ssPatch.Namespace, ssPatch.Name, ssPatch.Kind)
-							shouldReApply = true
-						}
-					}
+				// re-apply once this patch's own effective reapply interval has elapsed since it
+				// was last successfully applied, unless the patch apply mode is apply once, and
+				// only on a reconcile that won the cluster sync lease this cycle; see
+				// claimClusterSyncLease and periodicReapplyDue above.
+				if ssPatch.ApplyMode != hivev1.ApplyOncePatchApplyMode && periodicReapplyDue && dueForPeriodicReapply(pss.Conditions, effectiveInterval) {
+					ssLog.Debugf("patch %s/%s (%s) is due for periodic reapply", ssPatch.Namespace, ssPatch.Name, ssPatch.Kind)
+					shouldReApply = true
 				}
 				break
 			}
@@ -557,96 +782,164 @@ func (r *ReconcileSyncSet) applySyncSetPatches(ssPatches []hivev1.SyncObjectPatc
 				Name:      ssPatch.Name,
 				Namespace: ssPatch.Namespace,
 			}
+			start := time.Now()
 			err := h.Patch(namespacedName, ssPatch.Kind, ssPatch.APIVersion, []byte(ssPatch.Patch), ssPatch.PatchType)
-			patchSyncStatus.Conditions = r.setApplySyncConditions(patchSyncConditions, err)
+			observeApplyDuration(operationPatch, ssPatch.Kind, ssPatch.APIVersion, start)
+			patchSyncStatus.Conditions = r.setApplySyncConditions(patchSyncConditions, err, generation)
+			patchSyncStatus.ObservedGeneration = generation
 			syncSetStatus.Patches = appendOrUpdateSyncStatus(syncSetStatus.Patches, patchSyncStatus)
 			if err != nil {
+				metricResourcesApplied.WithLabelValues(operationPatch, resultFailed).Inc()
 				return err
 			}
+			metricResourcesApplied.WithLabelValues(operationPatch, resultApplied).Inc()
+		} else {
+			metricResourcesApplied.WithLabelValues(operationPatch, resultUnchanged).Inc()
 		}
 	}
 	return nil
 }
 
-func (r *ReconcileSyncSet) reconcileDeletedSyncSetResources(applyMode hivev1.SyncSetResourceApplyMode, dynamicClient dynamic.Interface, existingStatusList, newStatusList []hivev1.SyncStatus, err error, ssLog log.FieldLogger) ([]hivev1.SyncStatus, error) {
+// reconcileDeletedSyncSetResources garbage collects resources that are no longer part of the
+// SyncSet. Rather than trusting the previous ClusterSync.Status to enumerate what's out
+// there, it lists objects on the target cluster carrying the syncSetIDLabel for every resource
+// kind the SyncSet touches, and deletes whatever isn't in the desired set. This keeps GC correct
+// even if ClusterSync.Status was lost or truncated.
+//
+// Garbage collection only ever runs for ResourceApplyMode Sync; applyMode "" and Upsert retain
+// orphans exactly like before PruneMode existed, so an existing SyncSet that has never set either
+// field keeps its old behavior unchanged. PruneMode only narrows further within Sync mode: Retain
+// opts a Sync-mode SyncSet back out of deletion without having to drop back to Upsert.
+//
+// Objects applied by a SyncSet before syncSetIDLabel was introduced won't carry it, so they are
+// invisible to this label query and will not be found or deleted here. They self-heal on the next
+// apply though: such an object predates syncSetChecksumAnnotation too, so liveResourceChecksum
+// never matches and the object gets re-applied and re-stamped with syncSetIDLabel. Sync-mode
+// pruning only sees a SyncSet's full previously-applied set once that has happened.
+func (r *ReconcileSyncSet) reconcileDeletedSyncSetResources(applyMode hivev1.SyncSetResourceApplyMode, pruneMode hivev1.SyncSetResourcePruneMode, dynamicClient dynamic.Interface, syncSetID string, existingStatusList, newStatusList []hivev1.SyncStatus, err error, ssLog log.FieldLogger) ([]hivev1.SyncStatus, error) {
 	ssLog.Debugf("reconciling syncset resources, existing: %d, actual: %d", len(existingStatusList), len(newStatusList))
-	if applyMode == "" || applyMode == hivev1.UpsertResourceApplyMode {
-		ssLog.Debugf("apply mode is upsert, syncset status will be updated")
+	if applyMode != hivev1.SyncResourceApplyMode {
+		ssLog.Debugf("apply mode is not sync, syncset status will be updated without garbage collection")
 		return newStatusList, nil
 	}
-	deletedStatusList := []hivev1.SyncStatus{}
-	deletedStatusIndices := []int{}
-	for i, existingStatus := range existingStatusList {
-		found := false
-		for _, newStatus := range newStatusList {
-			if existingStatus.Name == newStatus.Name &&
-				existingStatus.Namespace == newStatus.Namespace &&
-				existingStatus.APIVersion == newStatus.APIVersion &&
-				existingStatus.Kind == newStatus.Kind {
-				found = true
-				break
-			}
-		}
-		if !found {
-			ssLog.WithField("resource", fmt.Sprintf("%s/%s", existingStatus.Namespace, existingStatus.Name)).
-				WithField("apiversion", existingStatus.APIVersion).
-				WithField("kind", existingStatus.Kind).Debug("resource not found in updated status, will queue up for deletion")
-			deletedStatusList = append(deletedStatusList, existingStatus)
-			deletedStatusIndices = append(deletedStatusIndices, i)
-		}
+	if pruneMode == hivev1.RetainResourcePruneMode {
+		ssLog.Debugf("prune mode is retain, syncset status will be updated without garbage collection")
+		return newStatusList, nil
 	}
 
 	// If an error occurred applying resources, do not delete yet
 	if err != nil {
-		ssLog.Debugf("an error occurred applying resources, will preserve all syncset status items")
-		return append(newStatusList, deletedStatusList...), nil
+		ssLog.Debugf("an error occurred applying resources, will preserve syncset status and skip garbage collection")
+		return newStatusList, nil
+	}
+
+	desired := map[string]bool{}
+	for _, s := range newStatusList {
+		desired[syncStatusKey(s.APIVersion, s.Kind, s.Namespace, s.Name)] = true
 	}
 
-	for i, deletedStatus := range deletedStatusList {
-		itemLog := ssLog.WithField("resource", fmt.Sprintf("%s/%s", deletedStatus.Namespace, deletedStatus.Name)).
-			WithField("apiversion", deletedStatus.APIVersion).
-			WithField("kind", deletedStatus.Kind)
-		gv, err := schema.ParseGroupVersion(deletedStatus.APIVersion)
+	gvrs := map[schema.GroupVersionResource]bool{}
+	for _, s := range existingStatusList {
+		gv, err := schema.ParseGroupVersion(s.APIVersion)
 		if err != nil {
 			return nil, err
 		}
-		gvr := gv.WithResource(deletedStatus.Resource)
-		itemLog.Debug("deleting resource")
-		err = dynamicClient.Resource(gvr).Namespace(deletedStatus.Namespace).Delete(deletedStatus.Name, &metav1.DeleteOptions{})
+		gvrs[gv.WithResource(s.Resource)] = true
+	}
+	for _, s := range newStatusList {
+		gv, err := schema.ParseGroupVersion(s.APIVersion)
 		if err != nil {
-			if !errors.IsNotFound(err) {
-				itemLog.WithError(err).Error("error deleting resource")
-				index := deletedStatusIndices[i]
-				existingStatusList[index].Conditions = r.setDeletionFailedSyncCondition(existingStatusList[index].Conditions, err)
-			} else {
-				itemLog.Debug("resource not found, nothing to do")
+			return nil, err
+		}
+		gvrs[gv.WithResource(s.Resource)] = true
+	}
+
+	selector := fmt.Sprintf("%s=%s", syncSetIDLabel, syncSetID)
+	for gvr := range gvrs {
+		list, err := dynamicClient.Resource(gvr).List(metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			ssLog.WithError(err).WithField("resource", gvr.String()).Error("error listing syncset-labeled resources")
+			continue
+		}
+		for _, item := range list.Items {
+			if desired[syncStatusKey(gvr.GroupVersion().String(), item.GetKind(), item.GetNamespace(), item.GetName())] {
+				continue
 			}
+			itemLog := ssLog.WithField("resource", fmt.Sprintf("%s/%s", item.GetNamespace(), item.GetName())).
+				WithField("apiversion", gvr.GroupVersion().String()).
+				WithField("kind", item.GetKind())
+			itemLog.Debug("deleting orphaned resource")
+			start := time.Now()
+			delErr := dynamicClient.Resource(gvr).Namespace(item.GetNamespace()).Delete(item.GetName(), &metav1.DeleteOptions{})
+			observeApplyDuration(operationDeleted, item.GetKind(), gvr.GroupVersion().String(), start)
+			if delErr != nil {
+				if !errors.IsNotFound(delErr) {
+					itemLog.WithError(delErr).Error("error deleting resource")
+					metricResourcesApplied.WithLabelValues(operationDeleted, resultFailed).Inc()
+				} else {
+					itemLog.Debug("resource not found, nothing to do")
+				}
+				continue
+			}
+			metricResourcesApplied.WithLabelValues(operationDeleted, resultDeleted).Inc()
 		}
 	}
 
 	return newStatusList, nil
 }
 
-func (r *ReconcileSyncSet) deleteSyncSetResources(ssResources []runtime.RawExtension, syncSetStatus hivev1.SyncSetObjectStatus, dynamicClient dynamic.Interface, ssLog log.FieldLogger) error {
+// syncStatusKey builds a comparison key for a resource identified by its apiVersion, kind,
+// namespace and name, used to diff desired SyncStatus entries against live cluster objects.
+func syncStatusKey(apiVersion, kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", apiVersion, kind, namespace, name)
+}
+
+// deleteSyncSetResources tears down every resource a SyncSet or SelectorSyncSet applied, ahead of
+// removing its cleanup finalizer. Rather than walking syncSetStatus.Resources (which may have been
+// truncated or lost), it lists by syncSetIDLabel across every resource kind the SyncSet currently
+// or previously referenced and deletes whatever it finds, making cleanup idempotent regardless of
+// whether ClusterSync.Status survived.
+func (r *ReconcileSyncSet) deleteSyncSetResources(ssResources []runtime.RawExtension, syncSetStatus hivev1.SyncSetObjectStatus, applier Applier, dynamicClient dynamic.Interface, syncSetID string, ssLog log.FieldLogger) error {
+	gvrs := map[schema.GroupVersionResource]bool{}
 	for _, resourceStatus := range syncSetStatus.Resources {
-		itemLog := ssLog.WithField("resource", fmt.Sprintf("%s/%s", resourceStatus.Namespace, resourceStatus.Name)).
-			WithField("apiversion", resourceStatus.APIVersion).
-			WithField("kind", resourceStatus.Kind)
 		gv, err := schema.ParseGroupVersion(resourceStatus.APIVersion)
 		if err != nil {
-			// continue instead if the goal is a brute force cleanup?
-			return err
+			continue
 		}
-		gvr := gv.WithResource(resourceStatus.Resource)
-		itemLog.Debug("deleting resource")
-		err = dynamicClient.Resource(gvr).Namespace(resourceStatus.Namespace).Delete(resourceStatus.Name, &metav1.DeleteOptions{})
+		gvrs[gv.WithResource(resourceStatus.Resource)] = true
+	}
+	for _, resource := range ssResources {
+		info, err := applier.Info(resource.Raw)
 		if err != nil {
-			if !errors.IsNotFound(err) {
-				itemLog.WithError(err).Error("error deleting resource")
-				// what should we do when we encounter an error deleting resources for cleanup? set deletionfailed status?
-				// existingStatusList[index].Conditions = r.setDeletionFailedSyncCondition(existingStatusList[index].Conditions, err)
-			} else {
-				itemLog.Debug("resource not found, nothing to do")
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(info.APIVersion)
+		if err != nil {
+			continue
+		}
+		gvrs[gv.WithResource(info.Resource)] = true
+	}
+
+	selector := fmt.Sprintf("%s=%s", syncSetIDLabel, syncSetID)
+	for gvr := range gvrs {
+		list, err := dynamicClient.Resource(gvr).List(metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			ssLog.WithError(err).WithField("resource", gvr.String()).Error("error listing syncset-labeled resources")
+			continue
+		}
+		for _, item := range list.Items {
+			itemLog := ssLog.WithField("resource", fmt.Sprintf("%s/%s", item.GetNamespace(), item.GetName())).
+				WithField("apiversion", gvr.GroupVersion().String()).
+				WithField("kind", item.GetKind())
+			itemLog.Debug("deleting resource")
+			if err := dynamicClient.Resource(gvr).Namespace(item.GetNamespace()).Delete(item.GetName(), &metav1.DeleteOptions{}); err != nil {
+				if !errors.IsNotFound(err) {
+					itemLog.WithError(err).Error("error deleting resource")
+					// what should we do when we encounter an error deleting resources for cleanup? set deletionfailed status?
+					// existingStatusList[index].Conditions = r.setDeletionFailedSyncCondition(existingStatusList[index].Conditions, err)
+				} else {
+					itemLog.Debug("resource not found, nothing to do")
+				}
 			}
 		}
 	}
@@ -692,20 +985,130 @@ func removeSyncSetObjectStatus(statusList []hivev1.SyncSetObjectStatus, syncSetN
 	return statusList
 }
 
-func (r *ReconcileSyncSet) updateClusterDeploymentStatus(cd *hivev1.ClusterDeployment, origCD *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
-	// Update cluster deployment status if changed:
-	if !reflect.DeepEqual(cd.Status, origCD.Status) {
-		cdLog.Infof("status has changed, updating cluster deployment status")
-		err := r.Status().Update(context.TODO(), cd)
-		if err != nil {
-			cdLog.Errorf("error updating cluster deployment status: %v", err)
+// updateClusterSyncStatus writes the ClusterSync status computed this reconcile. Concurrent
+// reconciles of the same ClusterDeployment (e.g. a second replica racing us, or MaxConcurrentReconciles
+// processing overlapping work) can cause our Status().Update to hit a 409 conflict; rather than
+// requeueing and re-running every apply in the set, it retries by re-fetching the current
+// ClusterSync and re-applying only the SyncSets/SelectorSyncSets entries this reconcile actually
+// touched or removed (syncSetNames/selectorSyncSetNames/removedSyncSetNames) on top of it, so a
+// sibling SyncSet's concurrent status write is never clobbered.
+func (r *ReconcileSyncSet) updateClusterSyncStatus(clusterSync, origClusterSync *hiveintv1alpha1.ClusterSync, syncSetNames, selectorSyncSetNames, removedSyncSetNames []string, cdLog log.FieldLogger) error {
+	if reflect.DeepEqual(clusterSync.Status, origClusterSync.Status) {
+		return nil
+	}
+	cdLog.Infof("status has changed, updating cluster sync status")
+
+	touchedSyncSets := map[string]hivev1.SyncSetObjectStatus{}
+	for _, name := range syncSetNames {
+		touchedSyncSets[name] = findSyncSetStatus(name, clusterSync.Status.SyncSets)
+	}
+	touchedSelectorSyncSets := map[string]hivev1.SyncSetObjectStatus{}
+	for _, name := range selectorSyncSetNames {
+		touchedSelectorSyncSets[name] = findSyncSetStatus(name, clusterSync.Status.SelectorSyncSets)
+	}
+
+	key := types.NamespacedName{Name: clusterSync.Name, Namespace: clusterSync.Namespace}
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		latest := &hiveintv1alpha1.ClusterSync{}
+		if err := r.Get(context.TODO(), key, latest); err != nil {
 			return err
 		}
+		for _, name := range syncSetNames {
+			latest.Status.SyncSets = appendOrUpdateSyncSetObjectStatus(latest.Status.SyncSets, touchedSyncSets[name])
+		}
+		for _, name := range selectorSyncSetNames {
+			latest.Status.SelectorSyncSets = appendOrUpdateSyncSetObjectStatus(latest.Status.SelectorSyncSets, touchedSelectorSyncSets[name])
+		}
+		for _, name := range removedSyncSetNames {
+			latest.Status.SyncSets = removeSyncSetObjectStatus(latest.Status.SyncSets, name)
+		}
+		latest.Status.Conditions = clusterSync.Status.Conditions
+		latest.Status.ResourceStatuses = clusterSync.Status.ResourceStatuses
+		latest.Status.SyncSetResourcesReady = clusterSync.Status.SyncSetResourcesReady
+		return r.Status().Update(context.TODO(), latest)
+	})
+	if err != nil {
+		cdLog.Errorf("error updating cluster sync status: %v", err)
+		return err
 	}
 	return nil
 }
 
-func (r *ReconcileSyncSet) setUnknownObjectSyncCondition(syncSetConditions []hivev1.SyncCondition, err error, index int) []hivev1.SyncCondition {
+// getOrCreateClusterSync fetches the ClusterSync for the given ClusterDeployment, creating it if
+// it does not yet exist. ClusterSync has the same name and namespace as its ClusterDeployment.
+func (r *ReconcileSyncSet) getOrCreateClusterSync(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*hiveintv1alpha1.ClusterSync, error) {
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	err := r.Get(context.TODO(), types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}, clusterSync)
+	if err == nil {
+		return clusterSync, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+	cdLog.Info("creating new cluster sync for cluster deployment")
+	clusterSync = &hiveintv1alpha1.ClusterSync{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cd.Name,
+			Namespace: cd.Namespace,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cd, clusterSync, r.scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Create(context.TODO(), clusterSync); err != nil {
+		return nil, err
+	}
+	return clusterSync, nil
+}
+
+// getOrCreateClusterSyncLease fetches the ClusterSyncLease for the given ClusterDeployment,
+// creating it if it does not yet exist. ClusterSyncLease has the same name and namespace as its
+// ClusterDeployment.
+func (r *ReconcileSyncSet) getOrCreateClusterSyncLease(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*hiveintv1alpha1.ClusterSyncLease, error) {
+	lease := &hiveintv1alpha1.ClusterSyncLease{}
+	err := r.Get(context.TODO(), types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}, lease)
+	if err == nil {
+		return lease, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+	cdLog.Info("creating new cluster sync lease for cluster deployment")
+	lease = &hiveintv1alpha1.ClusterSyncLease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cd.Name,
+			Namespace: cd.Namespace,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cd, lease, r.scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Create(context.TODO(), lease); err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+// claimClusterSyncLease attempts to renew the lease's RenewTime for this reconcile, so only one
+// replica pays the cost of the periodic reapply this cycle. If another hive replica renews it
+// first, our update conflicts and we skip the periodic reapply for this cycle; this only
+// deduplicates the periodic, nothing-changed reapply, it does not partition or gate applies driven
+// by an actual SyncSet change, which every replica that reconciles this ClusterDeployment performs.
+func (r *ReconcileSyncSet) claimClusterSyncLease(lease *hiveintv1alpha1.ClusterSyncLease, cdLog log.FieldLogger) (bool, error) {
+	now := metav1.NewMicroTime(time.Now())
+	lease.Spec.RenewTime = &now
+	err := r.Update(context.TODO(), lease)
+	if err == nil {
+		return true, nil
+	}
+	if errors.IsConflict(err) {
+		cdLog.Debug("cluster sync lease was claimed by another replica this cycle")
+		return false, nil
+	}
+	return false, err
+}
+
+func (r *ReconcileSyncSet) setUnknownObjectSyncCondition(syncSetConditions []hivev1.SyncCondition, err error, index int, generation int64) []hivev1.SyncCondition {
 	status := corev1.ConditionFalse
 	reason := unknownObjectNotFoundReason
 	message := fmt.Sprintf("Info available for all SyncSet resources")
@@ -721,13 +1124,77 @@ func (r *ReconcileSyncSet) setUnknownObjectSyncCondition(syncSetConditions []hiv
 		reason,
 		message,
 		controllerutils.UpdateConditionNever)
-	return syncSetConditions
+	return setObservedGeneration(syncSetConditions, hivev1.UnknownObjectSyncCondition, generation)
+}
+
+// setObservedGeneration stamps ObservedGeneration onto the named condition, if present, with the
+// generation of the SyncSet or SelectorSyncSet that produced it. This lets a consumer tell whether
+// a condition reflects the latest spec or one that predates a more recent edit.
+func setObservedGeneration(conditions []hivev1.SyncCondition, condType hivev1.SyncConditionType, generation int64) []hivev1.SyncCondition {
+	for i, cond := range conditions {
+		if cond.Type == condType {
+			conditions[i].ObservedGeneration = generation
+		}
+	}
+	return conditions
+}
+
+// setSuspendedSyncCondition sets the SyncSetPausedCondition reflecting whether a SyncSet or
+// SelectorSyncSet currently has spec.suspend set. Pausing skips apply and orphan pruning for the
+// SyncSet entirely (the standard GitOps "freeze" operators reach for during maintenance windows or
+// incident response) but condition reporting continues so the pause is visible in status.
+func setSuspendedSyncCondition(syncSetConditions []hivev1.SyncCondition, suspended bool, generation int64) []hivev1.SyncCondition {
+	status := corev1.ConditionFalse
+	reason := "SyncSetNotPaused"
+	message := "SyncSet is not paused"
+	if suspended {
+		status = corev1.ConditionTrue
+		reason = "SyncSetPaused"
+		message = "SyncSet is paused due to spec.suspend"
+	}
+	syncSetConditions = controllerutils.SetSyncCondition(
+		syncSetConditions,
+		hivev1.SyncSetPausedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	return setObservedGeneration(syncSetConditions, hivev1.SyncSetPausedCondition, generation)
 }
 
-func (r *ReconcileSyncSet) setApplySyncConditions(resourceSyncConditions []hivev1.SyncCondition, err error) []hivev1.SyncCondition {
+// classifyApplyFailure maps an apply failure into the stable reason and message to surface on
+// ApplyFailureSyncCondition, and whether the failure is permanent (non-retriable) and should set
+// PermanentApplyFailureSyncCondition so the work queue backs off aggressively instead of
+// hotlooping on a manifest that will never apply cleanly. Errors that never went through
+// classifyApplyError (or whose Kind is unrecognized) fall back to the pre-existing generic
+// behavior.
+func classifyApplyFailure(err error) (reason, message string, permanent bool) {
+	applyErr, ok := err.(*ApplyError)
+	if !ok {
+		return applyFailedReason, fmt.Sprintf("Apply failed: %v", err), false
+	}
+	switch applyErr.Kind {
+	case ApplyErrorConflict:
+		reason = applyErrorReasonConflict
+	case ApplyErrorInvalid:
+		reason = applyErrorReasonInvalid
+	case ApplyErrorForbidden:
+		reason = applyErrorReasonForbidden
+	case ApplyErrorNotFound:
+		reason = applyErrorReasonNotFound
+	case ApplyErrorServerTimeout:
+		reason = applyErrorReasonServerTimeout
+	default:
+		reason = applyFailedReason
+	}
+	return reason, fmt.Sprintf("Apply failed: %v", applyErr), !applyErr.Retriable
+}
+
+func (r *ReconcileSyncSet) setApplySyncConditions(resourceSyncConditions []hivev1.SyncCondition, err error, generation int64) []hivev1.SyncCondition {
 	var reason, message string
 	var successStatus, failureStatus corev1.ConditionStatus
 	var updateCondition controllerutils.UpdateConditionCheck
+	permanent := false
 	if err == nil {
 		reason = applySucceededReason
 		message = "Apply successful"
@@ -735,11 +1202,10 @@ func (r *ReconcileSyncSet) setApplySyncConditions(resourceSyncConditions []hivev
 		failureStatus = corev1.ConditionFalse
 		updateCondition = controllerutils.UpdateConditionAlways
 	} else {
-		reason = applyFailedReason
-		// TODO: we cannot include the actual error here as it currently contains a temp filename which always changes,
-		// which triggers a hotloop by always updating status and then reconciling again. If we were to filter out the portion
-		// of the error message with filename, we could re-add this here.
-		message = "Apply failed"
+		// Now that apply runs in-process against the dynamic client rather than shelling out to a
+		// tool that embeds a changing temp filename in its errors, the message is stable across
+		// reconciles and safe to surface directly without hotlooping status updates.
+		reason, message, permanent = classifyApplyFailure(err)
 		successStatus = corev1.ConditionFalse
 		failureStatus = corev1.ConditionTrue
 		updateCondition = controllerutils.UpdateConditionIfReasonOrMessageChange
@@ -769,20 +1235,50 @@ func (r *ReconcileSyncSet) setApplySyncConditions(resourceSyncConditions []hivev
 		reason,
 		message,
 		updateCondition)
+
+	resourceSyncConditions = setPermanentApplyFailureCondition(resourceSyncConditions, permanent, message)
+
+	resourceSyncConditions = setObservedGeneration(resourceSyncConditions, hivev1.ApplySuccessSyncCondition, generation)
+	resourceSyncConditions = setObservedGeneration(resourceSyncConditions, hivev1.ApplyFailureSyncCondition, generation)
+	resourceSyncConditions = setObservedGeneration(resourceSyncConditions, hivev1.DeletionFailedSyncCondition, generation)
+	resourceSyncConditions = setObservedGeneration(resourceSyncConditions, hivev1.PermanentApplyFailureSyncCondition, generation)
 	return resourceSyncConditions
 }
 
-func (r *ReconcileSyncSet) setDeletionFailedSyncCondition(resourceSyncConditions []hivev1.SyncCondition, err error) []hivev1.SyncCondition {
+// setPermanentApplyFailureCondition sets PermanentApplyFailureSyncCondition to reflect whether the
+// most recent apply failure is non-retriable (e.g. Invalid or Forbidden), so the controller's
+// work queue rate limiter can back off aggressively instead of hotlooping on a manifest that will
+// never apply cleanly.
+func setPermanentApplyFailureCondition(resourceSyncConditions []hivev1.SyncCondition, permanent bool, message string) []hivev1.SyncCondition {
+	status := corev1.ConditionFalse
+	permReason := notPermanentApplyFailureReason
+	permMessage := "Apply failure, if any, is considered retriable"
+	if permanent {
+		status = corev1.ConditionTrue
+		permReason = permanentApplyFailureReason
+		permMessage = message
+	}
+	return controllerutils.SetSyncCondition(
+		resourceSyncConditions,
+		hivev1.PermanentApplyFailureSyncCondition,
+		status,
+		permReason,
+		permMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+}
+
+func (r *ReconcileSyncSet) setDeletionFailedSyncCondition(resourceSyncConditions []hivev1.SyncCondition, err error, generation int64) []hivev1.SyncCondition {
 	if err == nil {
 		return resourceSyncConditions
 	}
-	return controllerutils.SetSyncCondition(
+	resourceSyncConditions = controllerutils.SetSyncCondition(
 		resourceSyncConditions,
 		hivev1.DeletionFailedSyncCondition,
 		corev1.ConditionTrue,
 		deletionFailedReason,
 		fmt.Sprintf("Failed to delete resource: %v", err),
 		controllerutils.UpdateConditionAlways)
+	return setObservedGeneration(resourceSyncConditions, hivev1.DeletionFailedSyncCondition, generation)
 }
 
 func (r *ReconcileSyncSet) getRelatedSelectorSyncSets(cd *hivev1.ClusterDeployment) ([]hivev1.SelectorSyncSet, error) {
@@ -842,8 +1338,117 @@ func (r *ReconcileSyncSet) loadSecretData(secretName, namespace, dataKey string)
 	return string(retStr), nil
 }
 
+// resourceHash returns a checksum of a resource's raw desired content, stored as SyncStatus.Hash so
+// later reconciles can tell whether the SyncSet's copy of the resource has changed. It uses the
+// same sha256 algorithm as canonicalResourceChecksum, but over the raw, unstamped content: this one
+// tracks drift in the SyncSet spec itself, while canonicalResourceChecksum/liveResourceChecksum
+// track drift of the object actually applied to the target cluster.
 func (r *ReconcileSyncSet) resourceHash(data []byte) string {
-	return fmt.Sprintf("%x", md5.Sum(data))
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// effectiveReapplyInterval returns the SyncSet or SelectorSyncSet's own ReapplyInterval override
+// if it set one, falling back to the fleet-wide default otherwise. This lets users tune reapply
+// cadence per SyncSet (e.g. shorter for drift-sensitive RBAC, longer for large static manifests).
+func effectiveReapplyInterval(override *metav1.Duration) time.Duration {
+	if override != nil {
+		return override.Duration
+	}
+	return reapplyInterval
+}
+
+// dueForPeriodicReapply reports whether more than interval has elapsed since conditions last
+// recorded a successful apply. Each resource or patch is compared against its own LastProbeTime
+// rather than against the ClusterSyncLease's RenewTime: the lease only staggers which hive replica
+// evaluates periodic reapply for this cluster in a given cycle, it does not track how long it has
+// been since any particular resource was last (re)applied, so using it as the elapsed-time basis
+// either reapplies continuously (a short override is always "overdue" between lease renewals) or
+// never (a long override is capped by the fleet-wide renewal cadence). LastProbeTime is updated on
+// every successful apply regardless of whether the status it records changed, so a resource or
+// patch is reapplied on the first periodic-reapply sweep after its own effective interval has
+// elapsed. Sweeps only run when a reconcile claims the ClusterSyncLease, at most once per
+// reapplyInterval, so an override shorter than reapplyInterval still reapplies only about once per
+// reapplyInterval.
+func dueForPeriodicReapply(conditions []hivev1.SyncCondition, interval time.Duration) bool {
+	cond := controllerutils.FindSyncCondition(conditions, hivev1.ApplySuccessSyncCondition)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		return false
+	}
+	return time.Since(cond.LastProbeTime.Time) > interval
+}
+
+// syncSetID returns the value hive stamps as syncSetIDLabel on every object applied by the SyncSet
+// or SelectorSyncSet identified by namespace and name, and that orphan pruning and finalizer
+// cleanup later list by. "." is used rather than "/" because label values may not contain a
+// slash. SelectorSyncSets are cluster-scoped, so namespace is empty and the ID is just the name.
+func syncSetID(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return fmt.Sprintf("%s.%s", namespace, name)
+}
+
+// canonicalResourceChecksum stamps syncSetNameLabel and syncSetIDLabel onto raw and returns the
+// sha256 checksum hive stamps as syncSetChecksumAnnotation, along with the labeled object so the
+// caller can finish stamping it without re-decoding raw. Since encoding/json always marshals map
+// keys in sorted order, this is a stable, canonical checksum of the object's content plus the
+// labels hive adds, independent of the Hash stored in ClusterSync.Status.
+func canonicalResourceChecksum(raw []byte, syncSetName, syncSetID string) (string, *unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(raw, &u.Object); err != nil {
+		return "", nil, err
+	}
+
+	objLabels := u.GetLabels()
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	objLabels[syncSetNameLabel] = syncSetName
+	objLabels[syncSetIDLabel] = syncSetID
+	u.SetLabels(objLabels)
+
+	canonicalJSON, err := json.Marshal(u.Object)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(canonicalJSON)), u, nil
+}
+
+// stampSyncSetMetadata returns a copy of the given resource, marshaled to JSON, with
+// syncSetNameLabel, syncSetIDLabel and syncSetChecksumAnnotation set. Stamping every object hive
+// applies allows drift detection and garbage collection to be driven by listing the target
+// cluster rather than by trusting ClusterSync.Status to enumerate what's out there.
+func stampSyncSetMetadata(raw []byte, syncSetName, syncSetID string) ([]byte, error) {
+	checksum, u, err := canonicalResourceChecksum(raw, syncSetName, syncSetID)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := u.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[syncSetChecksumAnnotation] = checksum
+	u.SetAnnotations(annotations)
+
+	return json.Marshal(u.Object)
+}
+
+// liveResourceChecksum looks up the live object on the target cluster and returns the checksum
+// annotation hive previously stamped on it, if any. This lets applySyncSetResources recognize a
+// resource as already up to date even when ClusterSync.Status no longer has a record of it.
+func (r *ReconcileSyncSet) liveResourceChecksum(dynamicClient dynamic.Interface, info hiveresource.Info) (string, bool) {
+	gv, err := schema.ParseGroupVersion(info.APIVersion)
+	if err != nil {
+		return "", false
+	}
+	gvr := gv.WithResource(info.Resource)
+	obj, err := dynamicClient.Resource(gvr).Namespace(info.Namespace).Get(info.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", false
+	}
+	checksum, ok := obj.GetAnnotations()[syncSetChecksumAnnotation]
+	return checksum, ok
 }
 
 var (
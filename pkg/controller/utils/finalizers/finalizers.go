@@ -0,0 +1,86 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package finalizers provides helpers for adding and removing finalizers from objects using a
+// strategic-merge patch rather than a full Update, so callers don't clobber concurrent writers
+// (webhooks, other controllers) that may have mutated other parts of the object in the meantime.
+package finalizers
+
+import (
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HasFinalizer returns true if the given object has the given finalizer.
+func HasFinalizer(object metav1.Object, finalizer string) bool {
+	for _, f := range object.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// EnsureFinalizer patches the finalizer onto the object if it is not already present. object is
+// updated in place to reflect the patched finalizer list.
+func EnsureFinalizer(c client.Client, object runtime.Object, finalizer string) error {
+	metaObj := object.(metav1.Object)
+	if HasFinalizer(metaObj, finalizer) {
+		return nil
+	}
+	finalizers := sets.NewString(metaObj.GetFinalizers()...)
+	finalizers.Insert(finalizer)
+	return patchFinalizers(c, object, metaObj, finalizers.List())
+}
+
+// RemoveFinalizer patches the finalizer off of the object if it is present. object is updated in
+// place to reflect the patched finalizer list.
+func RemoveFinalizer(c client.Client, object runtime.Object, finalizer string) error {
+	metaObj := object.(metav1.Object)
+	if !HasFinalizer(metaObj, finalizer) {
+		return nil
+	}
+	finalizers := sets.NewString(metaObj.GetFinalizers()...)
+	finalizers.Delete(finalizer)
+	return patchFinalizers(c, object, metaObj, finalizers.List())
+}
+
+func patchFinalizers(c client.Client, object runtime.Object, metaObj metav1.Object, finalizers []string) error {
+	data, err := json.Marshal(struct {
+		Metadata struct {
+			Finalizers []string `json:"finalizers"`
+		} `json:"metadata"`
+	}{
+		Metadata: struct {
+			Finalizers []string `json:"finalizers"`
+		}{Finalizers: finalizers},
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.Patch(context.TODO(), object, client.ConstantPatch(types.StrategicMergePatchType, data)); err != nil {
+		return err
+	}
+	metaObj.SetFinalizers(finalizers)
+	return nil
+}